@@ -50,6 +50,45 @@ func BenchmarkSegmentIntNegIndex(b *testing.B) {
 	x = r
 }
 
+func BenchmarkSegmentIntNoise(b *testing.B) {
+	p := "/zero/id-42x"
+	var r int64
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_ = Segment(p, 1, &r)
+	}
+
+	x = r
+}
+
+func BenchmarkSegmentIntLongNoise(b *testing.B) {
+	p := "/zero/" + strings.Repeat("x", 256) + "42"
+	var r int64
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_ = Segment(p, 1, &r)
+	}
+
+	x = r
+}
+
+func BenchmarkSegmentFloatLongNoise(b *testing.B) {
+	p := "/zero/" + strings.Repeat("x", 256) + "4.2"
+	var r float64
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_ = Segment(p, 1, &r)
+	}
+
+	x = r
+}
+
 func BenchmarkSpan(b *testing.B) {
 	p := "/zero/1/2"
 	var r string
@@ -62,6 +101,85 @@ func BenchmarkSpan(b *testing.B) {
 	x = r
 }
 
+func BenchmarkAppendPathIndexes(b *testing.B) {
+	p := "/zero/1/2/key/nn4.4nn/5.5"
+	var r []int
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		r = AppendPathIndexes(nil, p)
+	}
+
+	x = r
+}
+
+func BenchmarkAppendPathIndexesReused(b *testing.B) {
+	p := "/zero/1/2/key/nn4.4nn/5.5"
+	r := make([]int, 0, 8)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		r = AppendPathIndexes(r[:0], p)
+	}
+
+	x = r
+}
+
+func BenchmarkParthParallel(b *testing.B) {
+	p := "/zero/1/2/key/nn4.4nn/5.5"
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		var r string
+		for pb.Next() {
+			pp := New(p)
+			pp.Segment(4, &r)
+		}
+	})
+}
+
+func BenchmarkAcquireParthParallel(b *testing.B) {
+	p := "/zero/1/2/key/nn4.4nn/5.5"
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		var r string
+		for pb.Next() {
+			pp := AcquireParth(p)
+			pp.Segment(4, &r)
+			ReleaseParth(pp)
+		}
+	})
+}
+
+func BenchmarkSpanLongPath(b *testing.B) {
+	p := "/zero/1/2" + strings.Repeat("/seg", 256)
+	var r string
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		r, _ = Span(p, 200, 202)
+	}
+
+	x = r
+}
+
+func BenchmarkSpanLongPathShort(b *testing.B) {
+	p := "/zero/1/2" + strings.Repeat("/seg", 256)
+	var r string
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		r, _ = Span(p, 1, 3)
+	}
+
+	x = r
+}
+
 func BenchmarkStdlibSegmentString(b *testing.B) {
 	p := "/zero/1"
 	var r string
@@ -195,3 +313,16 @@ func stdlibSpan(p string, i, j int) (string, error) {
 
 	return pfx + path.Join(cs[i:j]...), nil
 }
+
+func BenchmarkSegmentContains(b *testing.B) {
+	p := "/zero/o~ne/2"
+	var r bool
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		r, _ = SegmentContains(p, 1, "~")
+	}
+
+	x = r
+}
@@ -0,0 +1,22 @@
+package parth
+
+import (
+	"context"
+)
+
+type ctxKey struct{}
+
+// NewContext returns a copy of the provided context that carries p, so that a
+// path processed once (e.g. in middleware) can be reused by subsequent
+// handlers. Note that the methods of Parth record the first encountered error,
+// so a stored *Parth should not be used by multiple goroutines concurrently.
+func NewContext(ctx context.Context, p *Parth) context.Context {
+	return context.WithValue(ctx, ctxKey{}, p)
+}
+
+// FromContext returns the *Parth stored in the provided context by NewContext,
+// if any.
+func FromContext(ctx context.Context) (*Parth, bool) {
+	p, ok := ctx.Value(ctxKey{}).(*Parth)
+	return p, ok
+}
@@ -0,0 +1,33 @@
+package parth
+
+import (
+	"context"
+	"testing"
+)
+
+func TestBhvrContext(t *testing.T) {
+	t.Run("stored", func(t *testing.T) {
+		want := New("/zero/one")
+		ctx := NewContext(context.Background(), want)
+
+		got, ok := FromContext(ctx)
+		if !ok || got != want {
+			t.Fatalf(gwFmt, got, want)
+		}
+
+		var s string
+		got.Segment(1, &s)
+		unx(t, t.Name(), got.Err())
+
+		if s != "one" {
+			t.Errorf(gwFmt, s, "one")
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		got, ok := FromContext(context.Background())
+		if ok || got != nil {
+			t.Errorf(gwFmt, got, nil)
+		}
+	})
+}
@@ -159,3 +159,77 @@ func (m *mytype) UnmarshalSegment(seg string) error {
 	*m = []byte(seg)
 	return nil
 }
+
+func ExampleJoin() {
+	fmt.Println(parth.Join("zero", "/1/", "", "2/key"))
+
+	// Output:
+	// /zero/1/2/key
+}
+
+func ExampleReplaceSegment() {
+	s, err := parth.ReplaceSegment(r.URL.Path, -2, "nn6.6nn")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+
+	fmt.Println(r.URL.Path)
+	fmt.Println(s)
+
+	// Output:
+	// /zero/1/2/key/nn4.4nn/5.5
+	// /zero/1/2/key/nn6.6nn/5.5
+}
+
+func ExampleNewContext() {
+	ctx := parth.NewContext(r.Context(), parth.New(r.URL.Path))
+
+	// later, within a subsequent handler
+	p, ok := parth.FromContext(ctx)
+	if !ok {
+		fmt.Fprintln(os.Stderr, "no parth in context")
+		return
+	}
+
+	var s string
+	p.SubSeg("key", 0, &s)
+	if err := p.Err(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+
+	fmt.Println(s)
+
+	// Output:
+	// nn4.4nn
+}
+
+func ExampleScan() {
+	var id int
+	var name string
+	if err := parth.Scan("/7/bob", &id, &name); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+
+	fmt.Printf("%v (%T)\n", id, id)
+	fmt.Printf("%v (%T)\n", name, name)
+
+	// Output:
+	// 7 (int)
+	// bob (string)
+}
+
+func ExampleSegmentScanner() {
+	sc := parth.NewSegmentScanner("/api/v1/users/7")
+	for sc.Scan() {
+		if sc.Text() == "users" {
+			break
+		}
+	}
+
+	if next, ok := sc.Peek(); ok {
+		fmt.Println(sc.Index(), next)
+	}
+
+	// Output:
+	// 2 7
+}
@@ -1 +1,5 @@
 module github.com/codemodus/parth/v2
+
+go 1.18
+
+require golang.org/x/text v0.14.0
@@ -0,0 +1,269 @@
+package parth
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MatchGlob reports whether the path matches the provided glob pattern. The
+// pattern is compared segment by segment. A segment of "*" matches exactly one
+// path segment (including an empty one), so a trailing "*" requires the path
+// to have a segment in that position. A segment of "**" matches any number of
+// remaining path segments (including none) and may only appear as the final
+// segment of the pattern. All other pattern segments, including those that
+// merely contain an asterisk, must match literally. A single trailing slash is
+// ignored in both the pattern and the path. The only possible returned error is
+// ErrBadPattern, when "**" appears in a non-terminal position.
+func MatchGlob(pattern, path string) (bool, error) {
+	pattern, _ = cutTrailingSlash(pattern)
+	path, _ = cutTrailingSlash(path)
+
+	var pats []string
+	var err error
+	eachSeg(pattern, func(n int, seg string) bool {
+		if len(pats) > 0 && pats[len(pats)-1] == "**" {
+			err = ErrBadPattern
+			return false
+		}
+
+		pats = append(pats, seg)
+		return true
+	})
+	if err != nil {
+		return false, err
+	}
+
+	ok := true
+	ct := 0
+	eachSeg(path, func(n int, seg string) bool {
+		if n >= len(pats) {
+			ok = false
+			return false
+		}
+
+		ct++
+
+		switch pats[n] {
+		case "**":
+			return false
+		case "*":
+			return true
+		}
+
+		ok = pats[n] == seg
+		return ok
+	})
+	if !ok {
+		return false, nil
+	}
+
+	if ct < len(pats) {
+		return ct == len(pats)-1 && pats[ct] == "**", nil
+	}
+
+	return true, nil
+}
+
+// MatchAny tries each of the provided templates in order and returns the first
+// that matches the path along with the segments it captured. A template is
+// compared segment by segment. A segment of the form "{name}" matches exactly
+// one path segment and captures it as params[name], a final segment of "*"
+// matches any number of remaining path segments (including none) and captures
+// them without a leading slash as params["*"], and all other segments must
+// match literally (e.g. "/users/{id}/*" matches "/users/7/posts/9" with
+// {id: 7, *: posts/9}). A single trailing slash is ignored in both the
+// templates and the path. Templates with a non-terminal "*" never match. If no
+// template matches, ok is false.
+func MatchAny(path string, templates []string) (matched string, params map[string]string, ok bool) {
+	for _, t := range templates {
+		if params, ok = matchTemplate(path, t); ok {
+			return t, params, true
+		}
+	}
+
+	return "", nil, false
+}
+
+func matchTemplate(path, tmpl string) (map[string]string, bool) {
+	path, _ = cutTrailingSlash(path)
+	tmpl, _ = cutTrailingSlash(tmpl)
+
+	var segs []string
+	if editSegCount(path) > 0 {
+		eachSeg(path, func(_ int, seg string) bool {
+			segs = append(segs, seg)
+			return true
+		})
+	}
+
+	params := make(map[string]string)
+	ct := editSegCount(tmpl)
+	ok := true
+	eachSeg(tmpl, func(n int, tok string) bool {
+		switch {
+		case ct == 0:
+		case tok == "*":
+			if n != ct-1 || n > len(segs) {
+				ok = false
+				break
+			}
+
+			rest, _ := TrimPrefixSegments(path, n)
+			params["*"] = rest[1:]
+			ct = len(segs)
+		case n >= len(segs):
+			ok = false
+		case len(tok) > 2 && tok[0] == '{' && tok[len(tok)-1] == '}':
+			params[tok[1:len(tok)-1]] = segs[n]
+		default:
+			ok = tok == segs[n]
+		}
+
+		return ok
+	})
+
+	if !ok || ct != len(segs) {
+		return nil, false
+	}
+
+	return params, true
+}
+
+// ValidateShape reports whether each segment of the path can be unmarshaled as
+// the type indicated by the corresponding segment of the mask. The mask is a
+// path of type tokens: "s" (string), "i" (int), "f" (float), and "b" (bool)
+// (e.g. "/users/7/true" matches "s/i/b"). Segments are handled in the same
+// manner as with Segment, so an "i" segment need only contain an integer. A
+// nil error is returned when the path matches. Otherwise, an error wrapping
+// ErrShapeMismatch describes the first mismatch or the differing segment
+// counts. ErrBadPattern is returned if the mask contains an unknown token.
+func ValidateShape(path, mask string) error {
+	mask, _ = cutTrailingSlash(mask)
+	path, _ = cutTrailingSlash(path)
+
+	var toks []string
+	var err error
+	eachSeg(mask, func(n int, tok string) bool {
+		switch tok {
+		case "s", "i", "f", "b":
+			toks = append(toks, tok)
+			return true
+		}
+
+		err = ErrBadPattern
+		return false
+	})
+	if err != nil {
+		return err
+	}
+
+	if ct := editSegCount(path); ct != len(toks) {
+		return fmt.Errorf("%w: path has %d segments, mask has %d", ErrShapeMismatch, ct, len(toks))
+	}
+
+	for n, tok := range toks {
+		var kind string
+		switch tok {
+		case "i":
+			var v int64
+			err, kind = Segment(path, n, &v), "an int"
+		case "f":
+			var v float64
+			err, kind = Segment(path, n, &v), "a float"
+		case "b":
+			var v bool
+			err, kind = Segment(path, n, &v), "a bool"
+		}
+
+		if err != nil {
+			s, _ := segmentToString(path, n)
+			return fmt.Errorf("%w: segment %d (%q) is not %s", ErrShapeMismatch, n, s, kind)
+		}
+	}
+
+	return nil
+}
+
+// TotalSegments returns the number of segments in the path. A single trailing
+// slash is not counted as an empty final segment, and both empty and root
+// paths have no segments (e.g. "/a/b/" results in 2). See (*Parth).Len for a
+// count that follows the non-negative indexing of Segment instead.
+func TotalSegments(path string) int {
+	path, _ = cutTrailingSlash(path)
+
+	return editSegCount(path)
+}
+
+// ValidateSegmentCount reports whether the path has at least min and at most
+// max segments as counted by TotalSegments. A negative max imposes no upper
+// limit. A nil error is returned when the count is in range. Otherwise, an
+// error wrapping ErrShapeMismatch that names the actual count is returned.
+func ValidateSegmentCount(path string, min, max int) error {
+	ct := TotalSegments(path)
+	if ct < min || (max >= 0 && ct > max) {
+		return fmt.Errorf("%w: path has %d segments, want %d to %d", ErrShapeMismatch, ct, min, max)
+	}
+
+	return nil
+}
+
+// SwitchDefault is the key of the SegmentSwitch case that is invoked when no
+// other case matches.
+const SwitchDefault = "*"
+
+// SegmentSwitch locates the path segment indicated by the index i and invokes
+// the callback of the case that matches it, ignoring case, returning the
+// callback's error. Exactly one non-default key is expected to match a given
+// segment. If the segment is missing or no case matches, the SwitchDefault case
+// is invoked when present. Otherwise, an error is returned: the index error if
+// the segment is missing, or an error wrapping ErrUnknownEnum if no case
+// matches.
+func SegmentSwitch(path string, i int, cases map[string]func() error) error {
+	s, err := segmentToString(path, i)
+	if err == nil {
+		if fn, ok := cases[s]; ok && s != SwitchDefault {
+			return fn()
+		}
+
+		for k, fn := range cases {
+			if k != SwitchDefault && strings.EqualFold(k, s) {
+				return fn()
+			}
+		}
+	}
+
+	if fn, ok := cases[SwitchDefault]; ok {
+		return fn()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w %q", ErrUnknownEnum, s)
+}
+
+// SegmentToStringMatching locates the path segment indicated by the index i and
+// returns it if it is matched in its entirety by re, as though re were
+// anchored at both ends (e.g. "a|ab" matches "ab"). If re is nil, the segment
+// is returned as-is. An error is returned if: 1. The index is out of range of
+// the path; 2. The located path segment does not match, in which case the
+// error wraps ErrDataUnparsable and quotes both the segment and the pattern.
+func SegmentToStringMatching(path string, i int, re *regexp.Regexp) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil || re == nil {
+		return s, err
+	}
+
+	// A leftmost-longest match begins at 0 and spans the segment whenever any
+	// match does.
+	full := *re
+	full.Longest()
+
+	if loc := full.FindStringIndex(s); loc == nil || loc[0] != 0 || loc[1] != len(s) {
+		return "", fmt.Errorf("%w: segment %q does not match %q", ErrDataUnparsable, s, re.String())
+	}
+
+	return s, nil
+}
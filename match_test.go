@@ -0,0 +1,330 @@
+package parth
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestBhvrMatchGlob(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		path    string
+		want    bool
+		ck      checkFunc
+	}{
+		{"literal", "/api/users", "/api/users", true, unx},
+		{"literal mismatch", "/api/users", "/api/groups", false, unx},
+		{"star", "/api/*/users", "/api/v1/users", true, unx},
+		{"star empty segment", "/api/*/users", "/api//users", true, unx},
+		{"star mismatch", "/api/*/users", "/api/v1/groups", false, unx},
+		{"trailing star", "/api/*", "/api/v1", true, unx},
+		{"trailing star missing", "/api/*", "/api", false, unx},
+		{"trailing star extra", "/api/*", "/api/v1/users", false, unx},
+		{"double star", "/api/*/users/**", "/api/v1/users/7/posts", true, unx},
+		{"double star one", "/api/*/users/**", "/api/v1/users/7", true, unx},
+		{"double star none", "/api/*/users/**", "/api/v1/users", true, unx},
+		{"double star mismatch", "/api/*/users/**", "/api/v1/groups/7", false, unx},
+		{"double star only", "/**", "/any/thing", true, unx},
+		{"partial star is literal", "/api/v*", "/api/v1", false, unx},
+		{"partial star literal match", "/api/v*", "/api/v*", true, unx},
+		{"trailing slashes", "/api/*/", "/api/v1/", true, unx},
+		{"path shorter", "/api/v1/users", "/api/v1", false, unx},
+		{"path longer", "/api/v1", "/api/v1/users", false, unx},
+		{"double star non-terminal", "/api/**/users", "/api/v1/users", false, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := MatchGlob(tt.pattern, tt.path)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrValidateShape(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		mask string
+		ck   checkFunc
+	}{
+		{"match", "/users/7/true", "s/i/b", unx},
+		{"match leading slash mask", "/users/7/true", "/s/i/b", unx},
+		{"match float", "/price/3.5", "s/f", unx},
+		{"match int noise", "/users/id7", "s/i", unx},
+		{"match trailing slash", "/users/7/", "s/i", unx},
+		{"int mismatch", "/users/me/true", "s/i/b", exp},
+		{"bool mismatch", "/users/7/active", "s/i/b", exp},
+		{"float mismatch", "/price/abc", "s/f", exp},
+		{"fewer segments", "/users/7", "s/i/b", exp},
+		{"more segments", "/users/7/true/x", "s/i/b", exp},
+		{"bad token", "/users/7", "s/x", exp},
+	}
+
+	for _, tt := range tests {
+		err := ValidateShape(tt.path, tt.mask)
+		tt.ck(t, tt.name, err)
+	}
+
+	t.Run("message", func(t *testing.T) {
+		err := ValidateShape("/users/me/true", "s/i/b")
+		if !errors.Is(err, ErrShapeMismatch) {
+			t.Fatalf(gwFmt, err, ErrShapeMismatch)
+		}
+
+		want := `path does not match shape: segment 1 ("me") is not an int`
+		if err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+
+		err = ValidateShape("/users/7", "s/i/b")
+		want = "path does not match shape: path has 2 segments, mask has 3"
+		if err == nil || err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+
+		err = ValidateShape("/users/7", "s/x")
+		if !errors.Is(err, ErrBadPattern) {
+			t.Errorf(gwFmt, err, ErrBadPattern)
+		}
+	})
+}
+
+func TestBhvrSegmentSwitch(t *testing.T) {
+	errCase := errors.New("case error")
+
+	var got string
+	cases := map[string]func() error{
+		"list": func() error { got = "list"; return nil },
+		"Show": func() error { got = "show"; return nil },
+		"fail": func() error { return errCase },
+	}
+	withDefault := map[string]func() error{
+		"list":        cases["list"],
+		SwitchDefault: func() error { got = "default"; return nil },
+	}
+
+	tests := []struct {
+		name  string
+		path  string
+		i     int
+		cases map[string]func() error
+		want  string
+		ck    checkFunc
+	}{
+		{"exact", "/items/list", 1, cases, "list", unx},
+		{"fold", "/items/LIST", 1, cases, "list", unx},
+		{"fold key", "/items/show", 1, cases, "show", unx},
+		{"no match", "/items/other", 1, cases, "", exp},
+		{"missing", "/items", 1, cases, "", exp},
+		{"callback error", "/items/fail", 1, cases, "", exp},
+		{"default no match", "/items/other", 1, withDefault, "default", unx},
+		{"default missing", "/items", 1, withDefault, "default", unx},
+		{"default not literal", "/items/list", 1, withDefault, "list", unx},
+	}
+
+	for _, tt := range tests {
+		got = ""
+		err := SegmentSwitch(tt.path, tt.i, tt.cases)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		err := SegmentSwitch("/items/fail", 1, cases)
+		if !errors.Is(err, errCase) {
+			t.Errorf(gwFmt, err, errCase)
+		}
+
+		err = SegmentSwitch("/items/other", 1, cases)
+		if !errors.Is(err, ErrUnknownEnum) {
+			t.Errorf(gwFmt, err, ErrUnknownEnum)
+		}
+
+		err = SegmentSwitch("/items", 1, cases)
+		if !errors.Is(err, ErrFirstSegNotFound) {
+			t.Errorf(gwFmt, err, ErrFirstSegNotFound)
+		}
+	})
+}
+
+func TestBhvrSegmentToStringMatching(t *testing.T) {
+	slug := regexp.MustCompile(`[a-z0-9]+(?:-[a-z0-9]+)*`)
+
+	tests := []struct {
+		name string
+		path string
+		i    int
+		re   *regexp.Regexp
+		want string
+		ck   checkFunc
+	}{
+		{"slug", "/posts/hello-world", 1, slug, "hello-world", unx},
+		{"partial", "/posts/Hello-world", 1, slug, "", exp},
+		{"suffix", "/posts/hello-", 1, slug, "", exp},
+		{"anchored", "/posts/ab", 1, regexp.MustCompile(`^(?:a|ab)$`), "ab", unx},
+		{"leftmost shorter", "/posts/ab", 1, regexp.MustCompile(`a|ab`), "ab", unx},
+		{"alternation", "/posts/foo-bar", 1, regexp.MustCompile(`[a-z]+|[a-z]+-[a-z]+`), "foo-bar", unx},
+		{"lazy", "/posts/aaa", 1, regexp.MustCompile(`a+?`), "aaa", unx},
+		{"no full match", "/posts/abc", 1, regexp.MustCompile(`a|ab`), "", exp},
+		{"empty match", "/posts//", 1, regexp.MustCompile(`x*`), "", unx},
+		{"nil", "/posts/Any Thing", 1, nil, "Any Thing", unx},
+		{"bad index", "/posts", 1, slug, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringMatching(tt.path, tt.i, tt.re)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("re unchanged", func(t *testing.T) {
+		re := regexp.MustCompile(`a|ab`)
+		if _, err := SegmentToStringMatching("/posts/ab", 1, re); err != nil {
+			t.Fatalf(gwFmt, err, nil)
+		}
+
+		if got := re.FindString("ab"); got != "a" {
+			t.Errorf(gwFmt, got, "a")
+		}
+	})
+
+	t.Run("message", func(t *testing.T) {
+		_, err := SegmentToStringMatching("/posts/Hi", 1, regexp.MustCompile(`[a-z]+`))
+		if !errors.Is(err, ErrDataUnparsable) {
+			t.Fatalf(gwFmt, err, ErrDataUnparsable)
+		}
+
+		want := `data cannot be parsed: segment "Hi" does not match "[a-z]+"`
+		if err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+	})
+}
+
+func TestBhvrMatchAny(t *testing.T) {
+	templates := []string{
+		"/users/{id}",
+		"/users/{id}/posts/{post}",
+		"/users/me",
+		"/files/*",
+		"/a/*/b",
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		want   string
+		params map[string]string
+		okWant bool
+	}{
+		{"capture", "/users/7", "/users/{id}", map[string]string{"id": "7"}, true},
+		{"order wins", "/users/me", "/users/{id}", map[string]string{"id": "me"}, true},
+		{"two captures", "/users/7/posts/9/", "/users/{id}/posts/{post}", map[string]string{"id": "7", "post": "9"}, true},
+		{"wildcard", "/files/a/b.txt", "/files/*", map[string]string{"*": "a/b.txt"}, true},
+		{"wildcard empty", "/files", "/files/*", map[string]string{"*": ""}, true},
+		{"literal mismatch", "/groups/7", "", nil, false},
+		{"too long", "/users/7/posts", "", nil, false},
+		{"non-terminal wildcard", "/a/x/b", "", nil, false},
+		{"root", "/", "", nil, false},
+	}
+
+	for _, tt := range tests {
+		got, params, ok := MatchAny(tt.path, templates)
+		if ok != tt.okWant {
+			t.Errorf(gwxFmt, tt.name, ok, tt.okWant)
+			continue
+		}
+
+		if got != tt.want || !reflect.DeepEqual(params, tt.params) {
+			t.Errorf(gwxFmt, tt.name, []interface{}{got, params}, []interface{}{tt.want, tt.params})
+		}
+	}
+
+	t.Run("root templates", func(t *testing.T) {
+		got, params, ok := MatchAny("/", []string{"/*", "/"})
+		if !ok || got != "/*" || params["*"] != "" {
+			t.Errorf(gwFmt, []interface{}{got, params, ok}, "/*")
+		}
+
+		got, _, ok = MatchAny("/", []string{"/x", "/"})
+		if !ok || got != "/" {
+			t.Errorf(gwFmt, got, "/")
+		}
+	})
+}
+
+func TestBhvrTotalSegments(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{"empty", "", 0},
+		{"root", "/", 0},
+		{"one", "/a", 1},
+		{"no leading slash", "a/b", 2},
+		{"trailing slash", "/a/b/", 2},
+		{"empty segment", "/a//c", 3},
+		{"double trailing slash", "/a//", 2},
+	}
+
+	for _, tt := range tests {
+		if got := TotalSegments(tt.path); got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrValidateSegmentCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		min, max int
+		ck       checkFunc
+	}{
+		{"in range", "/a/b", 1, 3, unx},
+		{"at min", "/a", 1, 3, unx},
+		{"at max", "/a/b/c/", 1, 3, unx},
+		{"exact", "/a/b", 2, 2, unx},
+		{"no max", "/a/b/c/d/e", 2, -1, unx},
+		{"root zero", "/", 0, 0, unx},
+		{"under", "/a", 2, 3, exp},
+		{"over", "/a/b/c/d", 1, 3, exp},
+		{"empty under", "", 1, -1, exp},
+	}
+
+	for _, tt := range tests {
+		err := ValidateSegmentCount(tt.path, tt.min, tt.max)
+		tt.ck(t, tt.name, err)
+	}
+
+	t.Run("message", func(t *testing.T) {
+		err := ValidateSegmentCount("/a/b/c/d", 1, 3)
+		if !errors.Is(err, ErrShapeMismatch) {
+			t.Fatalf(gwFmt, err, ErrShapeMismatch)
+		}
+
+		want := "path does not match shape: path has 4 segments, want 1 to 3"
+		if err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+	})
+}
@@ -0,0 +1,230 @@
+package parth
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Join builds a path from the provided segments. The returned path begins
+// with a single slash and contains no consecutive slashes. Slashes found
+// within a segment are treated as separators, and empty segments are omitted.
+// Unlike path.Join, dot segments (e.g. "..") are kept as provided. If no
+// non-empty segments are provided, the root path "/" is returned.
+func Join(segments ...string) string {
+	var b strings.Builder
+
+	for _, seg := range segments {
+		for n := 0; n < len(seg); n++ {
+			if seg[n] == '/' {
+				continue
+			}
+
+			e := strings.IndexByte(seg[n:], '/')
+			if e < 0 {
+				e = len(seg) - n
+			}
+
+			b.WriteByte('/')
+			b.WriteString(seg[n : n+e])
+			n += e
+		}
+	}
+
+	if b.Len() == 0 {
+		return "/"
+	}
+
+	return b.String()
+}
+
+// ReplaceSegment returns a copy of the path with the segment indicated by the
+// index i replaced by value. If the index is negative, the negative count
+// begins with the last segment. A single trailing slash is treated as part of
+// the path shape rather than as an empty segment, so the presence of leading
+// and trailing slashes is preserved. The value is inserted literally (i.e. it
+// is not escaped). An error is returned if the index is out of range of the
+// path.
+func ReplaceSegment(path string, i int, value string) (string, error) {
+	p, tail := cutTrailingSlash(path)
+
+	f, l, ok := segBounds(p, i)
+	if !ok {
+		return "", ErrFirstSegNotFound
+	}
+
+	return p[:f] + value + p[l:] + tail, nil
+}
+
+// InsertSegment returns a copy of the path with value inserted as a new
+// segment at the index i. The segments previously at or after the index are
+// shifted by one. An index equal to the number of segments appends the value,
+// and an index of 0 prepends it (after the leading slash, if any). If the
+// index is negative, the negative count begins with the last segment (i.e. -1
+// inserts before the last segment). As with ReplaceSegment, the presence of
+// leading and trailing slashes is preserved. An error is returned if the index
+// is out of range of the path.
+func InsertSegment(path string, i int, value string) (string, error) {
+	p, tail := cutTrailingSlash(path)
+
+	ct := editSegCount(p)
+	if i < 0 {
+		i += ct
+	}
+	if i < 0 || i > ct {
+		return "", ErrFirstSegNotFound
+	}
+
+	if ct == 0 {
+		return p + value + tail, nil
+	}
+
+	if i == ct {
+		return p + "/" + value + tail, nil
+	}
+
+	f, _, ok := segBounds(p, i)
+	if !ok {
+		return "", ErrFirstSegNotFound
+	}
+
+	return p[:f] + value + "/" + p[f:] + tail, nil
+}
+
+// RemoveSegment returns a copy of the path with the segment indicated by the
+// index i removed. If the index is negative, the negative count begins with
+// the last segment. Removing the only segment of a path that begins with a
+// slash yields the root path "/". Otherwise, the presence of leading and
+// trailing slashes is preserved. An error is returned if the index is out of
+// range of the path.
+func RemoveSegment(path string, i int) (string, error) {
+	p, tail := cutTrailingSlash(path)
+
+	ct := editSegCount(p)
+	if ct == 0 {
+		return "", ErrFirstSegNotFound
+	}
+
+	f, l, ok := segBounds(p, i)
+	if !ok {
+		return "", ErrFirstSegNotFound
+	}
+
+	if ct == 1 {
+		return p[:f], nil
+	}
+
+	if l < len(p) {
+		return p[:f] + p[l+1:] + tail, nil
+	}
+
+	return p[:f-1] + tail, nil
+}
+
+// TrimPrefixSegments returns the remainder of the path after the first n
+// segments are removed (e.g. "/api/v2/users/7" with an n of 2 results in
+// "/users/7"). The remainder always begins with a slash, and removing every
+// segment yields the root path "/". An error is returned if n is negative or
+// greater than the number of segments in the path.
+func TrimPrefixSegments(path string, n int) (string, error) {
+	p, _ := cutTrailingSlash(path)
+
+	ct := editSegCount(p)
+	if n < 0 || n > ct {
+		return "", ErrFirstSegNotFound
+	}
+
+	if n == ct {
+		return "/", nil
+	}
+
+	f, _ := segStartIndexFromStart(path, n)
+	if path[f] != '/' {
+		return "/" + path, nil
+	}
+
+	return path[f:], nil
+}
+
+// ReverseSegments returns a copy of the path with the order of its segments
+// reversed (e.g. "/com/example/www" results in "/www/example/com"). Empty
+// segments (e.g. from consecutive slashes) are kept and moved like any other
+// segment. As with ReplaceSegment, the presence of leading and trailing slashes
+// is preserved.
+func ReverseSegments(path string) string {
+	p, tail := cutTrailingSlash(path)
+
+	var lead string
+	if p != "" && p[0] == '/' {
+		lead, p = "/", p[1:]
+	}
+
+	segs := strings.Split(p, "/")
+	for l, r := 0, len(segs)-1; l < r; l, r = l+1, r-1 {
+		segs[l], segs[r] = segs[r], segs[l]
+	}
+
+	return lead + strings.Join(segs, "/") + tail
+}
+
+// CollapseSlashes returns the path with each run of consecutive slashes
+// replaced by a single slash (e.g. "/a//b///c/" results in "/a/b/c/"). Unlike
+// path.Clean, dot segments are not resolved and a trailing slash is kept. If
+// the path contains no such runs, it is returned as-is.
+func CollapseSlashes(path string) string {
+	if !strings.Contains(path, "//") {
+		return path
+	}
+
+	var b strings.Builder
+	b.Grow(len(path) - 1)
+
+	for n := 0; n < len(path); n++ {
+		if path[n] == '/' && n > 0 && path[n-1] == '/' {
+			continue
+		}
+		b.WriteByte(path[n])
+	}
+
+	return b.String()
+}
+
+// PathOnly returns the path portion of the raw HTTP request target, so that
+// the result is suitable for use with the segment funcs (e.g. "/a/b?x=1#f" and
+// "http://host/a/b?x=1" both result in "/a/b"). When the target begins with a
+// scheme, it is parsed using url.Parse and its escaped path is returned, or
+// "/" if the URL has no path. Otherwise, the target is cut at the first "?" or
+// "#", and percent-encoding is left as-is.
+func PathOnly(target string) string {
+	if n := strings.Index(target, "://"); n > 0 && !strings.ContainsAny(target[:n], "/?#") {
+		if u, err := url.Parse(target); err == nil {
+			if p := u.EscapedPath(); p != "" {
+				return p
+			}
+			return "/"
+		}
+	}
+
+	if n := strings.IndexAny(target, "?#"); n >= 0 {
+		return target[:n]
+	}
+
+	return target
+}
+
+// editSegCount returns the number of segments in the path while treating the
+// root path as having none.
+func editSegCount(path string) int {
+	if path == "/" {
+		return 0
+	}
+
+	return segCount(path)
+}
+
+func cutTrailingSlash(path string) (string, string) {
+	if len(path) > 1 && path[len(path)-1] == '/' {
+		return path[:len(path)-1], "/"
+	}
+
+	return path, ""
+}
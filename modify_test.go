@@ -0,0 +1,246 @@
+package parth
+
+import "testing"
+
+func TestBhvrJoin(t *testing.T) {
+	tests := []struct {
+		name string
+		segs []string
+		want string
+	}{
+		{"basic", []string{"a", "b", "c"}, "/a/b/c"},
+		{"stray slashes", []string{"/a/", "//b", "c//"}, "/a/b/c"},
+		{"inner slashes", []string{"a/b", "c//d"}, "/a/b/c/d"},
+		{"empty segments", []string{"", "a", "", "b", "/"}, "/a/b"},
+		{"dot segments", []string{"a", "..", "b"}, "/a/../b"},
+		{"none", nil, "/"},
+		{"only empty", []string{"", "//"}, "/"},
+	}
+
+	for _, tt := range tests {
+		got := Join(tt.segs...)
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrReplaceSegment(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		i     int
+		value string
+		want  string
+		ck    checkFunc
+	}{
+		{"first", "/zero/one/two", 0, "x", "/x/one/two", unx},
+		{"middle", "/zero/one/two", 1, "x", "/zero/x/two", unx},
+		{"last", "/zero/one/two", 2, "x", "/zero/one/x", unx},
+		{"neg last", "/zero/one/two", -1, "x", "/zero/one/x", unx},
+		{"neg first", "/zero/one/two", -3, "x", "/x/one/two", unx},
+		{"trailing slash", "/zero/one/", 1, "x", "/zero/x/", unx},
+		{"trailing slash neg", "/zero/one/", -1, "x", "/zero/x/", unx},
+		{"no leading slash", "zero/one", 0, "x", "x/one", unx},
+		{"literal value", "/zero/one", 1, "a b%2F", "/zero/a b%2F", unx},
+		{"empty segment", "/zero//two", 1, "x", "/zero/x/two", unx},
+		{"root", "/", 0, "x", "/x", unx},
+		{"out of range", "/zero/one", 2, "x", "", exp},
+		{"neg out of range", "/zero/one", -3, "x", "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := ReplaceSegment(tt.path, tt.i, tt.value)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrInsertSegment(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		i     int
+		value string
+		want  string
+		ck    checkFunc
+	}{
+		{"prepend", "/zero/one", 0, "x", "/x/zero/one", unx},
+		{"middle", "/zero/one", 1, "x", "/zero/x/one", unx},
+		{"append", "/zero/one", 2, "x", "/zero/one/x", unx},
+		{"neg", "/zero/one", -1, "x", "/zero/x/one", unx},
+		{"neg first", "/zero/one", -2, "x", "/x/zero/one", unx},
+		{"trailing slash", "/zero/one/", 2, "x", "/zero/one/x/", unx},
+		{"trailing slash prepend", "/zero/one/", 0, "x", "/x/zero/one/", unx},
+		{"no leading slash", "zero/one", 0, "x", "x/zero/one", unx},
+		{"root", "/", 0, "x", "/x", unx},
+		{"empty", "", 0, "x", "x", unx},
+		{"out of range", "/zero/one", 3, "x", "", exp},
+		{"neg out of range", "/zero/one", -3, "x", "", exp},
+		{"root out of range", "/", 1, "x", "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := InsertSegment(tt.path, tt.i, tt.value)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrRemoveSegment(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"first", "/zero/one/two", 0, "/one/two", unx},
+		{"middle", "/zero/one/two", 1, "/zero/two", unx},
+		{"last", "/zero/one/two", 2, "/zero/one", unx},
+		{"neg last", "/zero/one/two", -1, "/zero/one", unx},
+		{"trailing slash", "/zero/one/", 1, "/zero/", unx},
+		{"trailing slash first", "/zero/one/", 0, "/one/", unx},
+		{"no leading slash", "zero/one", 0, "one", unx},
+		{"empty segment", "/zero//two", 1, "/zero/two", unx},
+		{"only", "/zero", 0, "/", unx},
+		{"only trailing slash", "/zero/", 0, "/", unx},
+		{"only no leading slash", "zero", 0, "", unx},
+		{"root", "/", 0, "", exp},
+		{"out of range", "/zero/one", 2, "", exp},
+		{"neg out of range", "/zero/one", -3, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := RemoveSegment(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrTrimPrefixSegments(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		n    int
+		want string
+		ck   checkFunc
+	}{
+		{"mount", "/api/v2/users/7", 2, "/users/7", unx},
+		{"none", "/api/v2", 0, "/api/v2", unx},
+		{"all", "/api/v2", 2, "/", unx},
+		{"trailing slash", "/api/v2/users/", 2, "/users/", unx},
+		{"trailing slash all", "/api/v2/", 2, "/", unx},
+		{"no leading slash", "api/v2/users", 1, "/v2/users", unx},
+		{"no leading slash none", "api/v2", 0, "/api/v2", unx},
+		{"root", "/", 0, "/", unx},
+		{"too many", "/api/v2", 3, "", exp},
+		{"negative", "/api/v2", -1, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := TrimPrefixSegments(tt.path, tt.n)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrReverseSegments(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"basic", "/com/example/www", "/www/example/com"},
+		{"trailing slash", "/com/example/www/", "/www/example/com/"},
+		{"no leading slash", "com/example", "example/com"},
+		{"empty segment", "/a//b/c", "/c/b//a"},
+		{"single", "/a", "/a"},
+		{"root", "/", "/"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		got := ReverseSegments(tt.path)
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrCollapseSlashes(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"internal", "/a//b///c", "/a/b/c"},
+		{"leading", "//a/b", "/a/b"},
+		{"trailing", "/a/b//", "/a/b/"},
+		{"all runs", "///a//b///", "/a/b/"},
+		{"single trailing kept", "/a/b/", "/a/b/"},
+		{"dots kept", "/a//../b", "/a/../b"},
+		{"case kept", "/A//B", "/A/B"},
+		{"no leading slash", "a//b", "a/b"},
+		{"root run", "///", "/"},
+		{"unchanged", "/a/b", "/a/b"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		got := CollapseSlashes(tt.path)
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrPathOnly(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		want   string
+	}{
+		{"origin", "/a/b", "/a/b"},
+		{"origin query", "/a/b?x=1", "/a/b"},
+		{"origin fragment", "/a/b#f", "/a/b"},
+		{"origin query fragment", "/a/b?x=1#f", "/a/b"},
+		{"origin escaped", "/a%2Fb/c?x", "/a%2Fb/c"},
+		{"relative", "a/b?x=1", "a/b"},
+		{"relative no path", "?x=1", ""},
+		{"absolute", "http://host/a/b?x=1#f", "/a/b"},
+		{"absolute port", "https://host:8080/a/", "/a/"},
+		{"absolute escaped", "http://host/a%2Fb", "/a%2Fb"},
+		{"absolute no path", "http://host?x=1", "/"},
+		{"scheme in query", "/a?next=http://host/b", "/a"},
+		{"asterisk", "*", "*"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		got := PathOnly(tt.target)
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,645 @@
+package parth
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SegmentToFloat64Locale is similar to Segment when used with a *float64, but
+// treats the provided decimal separator (e.g. ',') as the decimal point. A
+// period is then treated as non-numeric data. Only ASCII separators are
+// supported. An error is returned if: 1. The index is out of range of the
+// path; 2. The separator is not ASCII or a float cannot be found within the
+// located path segment.
+func SegmentToFloat64Locale(path string, i int, decimalSep rune) (float64, error) {
+	if decimalSep >= utf8.RuneSelf || decimalSep < 0 {
+		return 0.0, ErrDataUnparsable
+	}
+
+	return segmentToFloatNSep(path, i, 64, byte(decimalSep))
+}
+
+// SegmentToIntGrouped is similar to Segment when used with an *int64, but
+// treats the provided grouping separator as part of the first integer when it
+// sits between digits, removing it before parsing (e.g. "1,000,000" with ','
+// results in 1000000). A trailing or doubled separator terminates the integer.
+// An error is returned if: 1. The index is out of range of the path; 2. The
+// separator is a digit or an integer cannot be found within the located path
+// segment; 3. The integer does not fit within an int64.
+func SegmentToIntGrouped(path string, i int, groupSep rune) (int64, error) {
+	ss, err := segmentToString(path, i)
+	if err != nil {
+		return 0, err
+	}
+
+	if unicode.IsDigit(groupSep) {
+		return 0, ErrDataUnparsable
+	}
+
+	s, next, ok := nextIntToken(ss, 0, true)
+	if !ok {
+		return 0, ErrDataUnparsable
+	}
+
+	sepLen := utf8.RuneLen(groupSep)
+	for sepLen > 0 && isDigit(s[len(s)-1]) {
+		r, _ := utf8.DecodeRuneInString(ss[next:])
+		if r != groupSep || next+sepLen >= len(ss) || !isDigit(ss[next+sepLen]) {
+			break
+		}
+
+		f := next + sepLen
+		next = f
+		for next < len(ss) && isDigit(ss[next]) {
+			next++
+		}
+		s += ss[f:next]
+	}
+
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, newParseError(i, s, err)
+	}
+
+	return v, nil
+}
+
+// SegmentToNumber locates the first number within the path segment indicated
+// by the index i and returns it as an int64 if it is integral, or as a float64
+// if it has a fractional or exponent part. An error is returned if: 1. The
+// index is out of range of the path; 2. A number cannot be found within the
+// located path segment or it cannot be represented by the detected type.
+func SegmentToNumber(path string, i int) (interface{}, error) {
+	ss, err := segmentToString(path, i)
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := firstFloatFromString(ss)
+	if !ok {
+		return nil, ErrDataUnparsable
+	}
+
+	if strings.ContainsAny(s, ".eE") {
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, newParseError(i, s, err)
+		}
+
+		return v, nil
+	}
+
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return nil, newParseError(i, s, err)
+	}
+
+	return v, nil
+}
+
+// SegmentToIntWithUnit locates the first integer within the path segment
+// indicated by the index i and multiplies it by the factor of the unit that
+// the segment ends with (e.g. "10MB" with units {"MB": 1 << 20}). When more
+// than one unit matches, the longest is used. If no unit matches, the integer
+// is returned as-is. An error is returned if: 1. The index is out of range of
+// the path; 2. An integer cannot be found within the located path segment; 3.
+// The result overflows an int64.
+func SegmentToIntWithUnit(path string, i int, units map[string]int64) (int64, error) {
+	ss, err := segmentToString(path, i)
+	if err != nil {
+		return 0, err
+	}
+
+	var unit string
+	f := int64(1)
+	for k, v := range units {
+		if len(k) > len(unit) && strings.HasSuffix(ss, k) {
+			unit, f = k, v
+		}
+	}
+
+	s, ok := firstIntFromString(ss[:len(ss)-len(unit)])
+	if !ok {
+		return 0, ErrDataUnparsable
+	}
+
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, newParseError(i, s, err)
+	}
+
+	r := v * f
+	if v != 0 && (r/v != f || f == -1 && v == math.MinInt64) {
+		return 0, ErrDataUnparsable
+	}
+
+	return r, nil
+}
+
+// SegmentToDurationUnit locates the path segment indicated by the index i and
+// parses it as a time.Duration. If the segment is a valid Go duration string
+// (e.g. "1m30s"), it is parsed with time.ParseDuration and unit is ignored.
+// Otherwise, the first integer within the segment is used as a count of unit
+// (e.g. "30" with time.Second results in 30s). An error is returned if: 1. The
+// index is out of range of the path; 2. The located path segment is neither a
+// duration string nor contains an integer; 3. The result overflows a
+// time.Duration.
+func SegmentToDurationUnit(path string, i int, unit time.Duration) (time.Duration, error) {
+	ss, err := segmentToString(path, i)
+	if err != nil {
+		return 0, err
+	}
+
+	if d, err := time.ParseDuration(ss); err == nil {
+		return d, nil
+	}
+
+	s, ok := firstIntFromString(ss)
+	if !ok {
+		return 0, ErrDataUnparsable
+	}
+
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, newParseError(i, s, err)
+	}
+
+	d := time.Duration(v) * unit
+	if v != 0 && (d/time.Duration(v) != unit || unit == -1 && v == math.MinInt64 || v == -1 && unit == math.MinInt64) {
+		return 0, ErrDataUnparsable
+	}
+
+	return d, nil
+}
+
+// SegmentsToIntSlice locates the first integer within each of the path
+// segments indicated by the provided indexes and returns them in the same order
+// (e.g. "/v/1/2/3/" with indexes 1, 2, 3 results in [1 2 3]). Segments are
+// handled in the same manner as with Segment. If no indexes are provided, an
+// empty slice is returned. An error naming the failing position is returned if
+// any index is out of range of the path or no integer can be parsed from any
+// located path segment.
+func SegmentsToIntSlice(path string, indexes ...int) ([]int64, error) {
+	vs := make([]int64, len(indexes))
+	for n, i := range indexes {
+		v, err := segmentToIntN(path, i, 64, NumberFirst)
+		if err != nil {
+			return nil, fmt.Errorf("position %d (segment %d): %w", n, i, err)
+		}
+		vs[n] = v
+	}
+
+	return vs, nil
+}
+
+// SegmentToFloats returns every float found within the path segment indicated
+// by the index i (e.g. "1.5,2.5,3.0" results in [1.5 2.5 3]). Floats are
+// delimited by any data that cannot be part of a float. An error is returned
+// if: 1. The index is out of range of the path; 2. No float can be found
+// within the located path segment or a found float cannot be parsed.
+func SegmentToFloats(path string, i int) ([]float64, error) {
+	ss, err := segmentToString(path, i)
+	if err != nil {
+		return nil, err
+	}
+
+	var vs []float64
+	for len(ss) > 0 {
+		ind, l, ok := firstFloatIndexSep(ss, '.')
+		if l == 0 {
+			break
+		}
+
+		s := ss[ind : ind+l]
+		ss = ss[ind+l:]
+		if !ok || s == "-" {
+			continue
+		}
+
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, newParseError(i, s, err)
+		}
+
+		vs = append(vs, v)
+	}
+
+	if len(vs) == 0 {
+		return nil, ErrDataUnparsable
+	}
+
+	return vs, nil
+}
+
+// SegmentToIntExact locates the path segment indicated by the index i and
+// parses the entirety of it as a base 10 integer. Unlike the numeric handling
+// of Segment, surrounding data is not skipped (e.g. "42x" is an error rather
+// than 42). An error is returned if: 1. The index is out of range of the path;
+// 2. The located path segment is not an integer that fits within an int64.
+func SegmentToIntExact(path string, i int) (int64, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return 0, err
+	}
+
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, newParseError(i, s, err)
+	}
+
+	return v, nil
+}
+
+// SegmentToIntStrictDigits is similar to SegmentToIntExact, but tolerates
+// trailing white space (e.g. "42 " results in 42). The located path segment
+// must otherwise consist only of ASCII digits with an optional leading sign, so
+// embedded punctuation or spaces (e.g. "42.0" or "4 2") result in an error
+// wrapping ErrDataUnparsable. An error is also returned if the index is out of
+// range of the path or the integer does not fit within an int64.
+func SegmentToIntStrictDigits(path string, i int) (int64, error) {
+	ss, err := segmentToString(path, i)
+	if err != nil {
+		return 0, err
+	}
+
+	s := strings.TrimRightFunc(ss, unicode.IsSpace)
+
+	digits := s
+	if digits != "" && (digits[0] == '-' || digits[0] == '+') {
+		digits = digits[1:]
+	}
+
+	if !isDigits(digits) {
+		return 0, fmt.Errorf("%w: %q is not an integer", ErrDataUnparsable, ss)
+	}
+
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, newParseError(i, s, err)
+	}
+
+	return v, nil
+}
+
+// SegmentToIntAuto is similar to Segment when used with an *int64, but
+// detects the base of the first integer from its prefix in the manner of
+// strconv.ParseInt with a base of 0: "0x" for hexadecimal, "0o" (or a bare
+// leading "0") for octal, "0b" for binary, and decimal otherwise (e.g. "0xff"
+// results in 255). An error is returned if: 1. The index is out of range of the
+// path; 2. An integer cannot be found within the located path segment; 3. The
+// integer does not fit within an int64.
+func SegmentToIntAuto(path string, i int) (int64, error) {
+	return SegmentToIntBaseBits(path, i, 0, 64)
+}
+
+// SegmentToIntBaseBits is similar to SegmentToIntAuto, but the first integer
+// is parsed using strconv.ParseInt with the provided base and bitSize (e.g.
+// "/reg/7f" with a base of 16 and a bitSize of 8 results in 127). A base of 0
+// detects the base from a prefix as with SegmentToIntAuto. An explicit base
+// does not require a prefix, though a leading "0x", "0o", or "0b" matching the
+// base is skipped. For bases above 10, letters are digits, so surrounding
+// letters that are valid in the base are part of the integer. An error is
+// returned if: 1. The index is out of range of the path; 2. An integer cannot
+// be found within the located path segment; 3. The integer does not fit within
+// bitSize bits, or the base or bitSize is invalid.
+func SegmentToIntBaseBits(path string, i, base, bitSize int) (int64, error) {
+	ss, err := segmentToString(path, i)
+	if err != nil {
+		return 0, err
+	}
+
+	var s string
+	var ok bool
+	if base == 0 {
+		s, ok = firstRadixIntFromString(ss)
+	} else {
+		s, ok = firstBaseIntFromString(ss, base)
+	}
+	if !ok {
+		return 0, ErrDataUnparsable
+	}
+
+	v, err := strconv.ParseInt(s, base, bitSize)
+	if err != nil {
+		return 0, newParseError(i, s, err)
+	}
+
+	return v, nil
+}
+
+// firstBaseIntFromString returns the first integer token in s that consists of
+// digits valid for base, including a leading sign but excluding a base prefix.
+func firstBaseIntFromString(s string, base int) (string, bool) {
+	isBaseDigit := func(c byte) bool { return digitValue(c) < base }
+
+	f, l, ok := intTokenIndex(s, 0, true, isBaseDigit)
+	if !ok {
+		return "", false
+	}
+
+	d := f
+	if s[d] == '-' {
+		d++
+	}
+
+	if l-d == 1 && s[d] == '0' && l+1 < len(s) && prefixBase(s[l]) == base && isBaseDigit(s[l+1]) {
+		p, e, _ := intTokenIndex(s, l+1, false, isBaseDigit)
+		return s[f:d] + s[p:e], true
+	}
+
+	return s[f:l], true
+}
+
+// digitValue returns the value of c as a digit of bases up to 36, or 36 if c
+// is not such a digit.
+func digitValue(c byte) int {
+	switch {
+	case isDigit(c):
+		return int(c - '0')
+	case 'a' <= c && c <= 'z':
+		return int(c-'a') + 10
+	case 'A' <= c && c <= 'Z':
+		return int(c-'A') + 10
+	}
+
+	return 36
+}
+
+// prefixBase returns the base indicated by the prefix letter c, or 0 if c is
+// not a prefix letter.
+func prefixBase(c byte) int {
+	switch c {
+	case 'x', 'X':
+		return 16
+	case 'o', 'O':
+		return 8
+	case 'b', 'B':
+		return 2
+	}
+
+	return 0
+}
+
+// firstRadixIntFromString returns the first integer token in s, including a
+// leading sign and any base prefix along with the digits valid for that base.
+func firstRadixIntFromString(s string) (string, bool) {
+	f, l, ok := intTokenIndex(s, 0, true, isDigit)
+	if !ok {
+		return "", false
+	}
+
+	d := f
+	if s[d] == '-' {
+		d++
+	}
+
+	if l-d == 1 && s[d] == '0' && l+1 < len(s) {
+		if fn := radixDigitFunc(s[l]); fn != nil && fn(s[l+1]) {
+			_, l, _ = intTokenIndex(s, l+1, false, fn)
+		}
+	}
+
+	return s[f:l], true
+}
+
+// radixDigitFunc returns a func reporting whether a byte is a digit of the
+// base indicated by the prefix letter c, or nil if c is not a prefix letter.
+func radixDigitFunc(c byte) func(byte) bool {
+	switch c {
+	case 'x', 'X':
+		return isHexDigit
+	case 'o', 'O':
+		return func(c byte) bool { return '0' <= c && c <= '7' }
+	case 'b', 'B':
+		return func(c byte) bool { return c == '0' || c == '1' }
+	}
+
+	return nil
+}
+
+// SegmentToFloat64Exact is similar to SegmentToIntExact, but parses the
+// located path segment as a decimal float using strconv.ParseFloat. Special
+// values (e.g. "NaN" or "Inf") and hexadecimal floats (e.g. "0x1p3") result in
+// an error wrapping ErrDataUnparsable.
+func SegmentToFloat64Exact(path string, i int) (float64, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return 0.0, err
+	}
+
+	if !isDecimalFloat(s) {
+		return 0.0, fmt.Errorf("%w: %q is not a decimal float", ErrDataUnparsable, s)
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0.0, newParseError(i, s, err)
+	}
+
+	return v, nil
+}
+
+// SpanToComplex128 locates the first float within each of the path segments
+// indicated by the indexes realSeg and imagSeg and combines them as the real
+// and imaginary parts of a complex number. If an index is negative, the
+// negative count begins with the last segment. An error is returned if either
+// segment cannot be found or parsed, in which case the error reports which
+// part failed and wraps the underlying error.
+func SpanToComplex128(path string, realSeg, imagSeg int) (complex128, error) {
+	re, err := segmentToFloatN(path, realSeg, 64, NumberFirst)
+	if err != nil {
+		return 0, fmt.Errorf("real part (segment %d): %w", realSeg, err)
+	}
+
+	im, err := segmentToFloatN(path, imagSeg, 64, NumberFirst)
+	if err != nil {
+		return 0, fmt.Errorf("imaginary part (segment %d): %w", imagSeg, err)
+	}
+
+	return complex(re, im), nil
+}
+
+// SegmentToIntDecoded is similar to Segment when used with an *int64, but
+// percent-decodes the located path segment using url.PathUnescape before
+// searching it for an integer (e.g. "%2D5" results in -5 rather than 25).
+// Segment and the other numeric functions treat segment data literally. An
+// error is returned if: 1. The index is out of range of the path; 2. The
+// located path segment cannot be decoded, in which case the decode error is
+// returned; 3. An integer cannot be found within the decoded segment.
+func SegmentToIntDecoded(path string, i int) (int64, error) {
+	ss, err := segmentToString(path, i)
+	if err != nil {
+		return 0, err
+	}
+
+	ss, err = url.PathUnescape(ss)
+	if err != nil {
+		return 0, err
+	}
+
+	s, ok := firstIntFromString(ss)
+	if !ok {
+		return 0, ErrDataUnparsable
+	}
+
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, newParseError(i, s, err)
+	}
+
+	return v, nil
+}
+
+// SegmentToFloat64Decoded is similar to SegmentToIntDecoded, but searches the
+// decoded segment for a float.
+func SegmentToFloat64Decoded(path string, i int) (float64, error) {
+	ss, err := segmentToString(path, i)
+	if err != nil {
+		return 0.0, err
+	}
+
+	ss, err = url.PathUnescape(ss)
+	if err != nil {
+		return 0.0, err
+	}
+
+	s, ok := firstFloatFromString(ss)
+	if !ok {
+		return 0.0, ErrDataUnparsable
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0.0, newParseError(i, s, err)
+	}
+
+	return v, nil
+}
+
+// SegmentToIntNoSign is similar to Segment when used with an *int64, but a
+// hyphen is never interpreted as a sign. Instead, it is treated as a literal
+// separator, so "a-5" results in 5 rather than -5. Because a hyphen that
+// begins a segment marks it as a name (e.g. "-5" meaning "minus-five"), such a
+// segment yields no integer. An error is returned if: 1. The index is out of
+// range of the path; 2. The located path segment begins with a hyphen or an
+// integer cannot be found within it.
+func SegmentToIntNoSign(path string, i int) (int64, error) {
+	ss, err := segmentToString(path, i)
+	if err != nil {
+		return 0, err
+	}
+
+	if ss != "" && ss[0] == '-' {
+		return 0, ErrDataUnparsable
+	}
+
+	s, ok := firstUintFromString(ss)
+	if !ok {
+		return 0, ErrDataUnparsable
+	}
+
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, newParseError(i, s, err)
+	}
+
+	return v, nil
+}
+
+// FindFirstInt returns the first integer token within s using the same rules
+// that Segment applies to a path segment when scanning for an int: the token
+// is the first run of ASCII digits, including a directly preceding '-', and
+// surrounding data is skipped (e.g. "id-42x7" results in "-42", and "a-b5"
+// results in "5"). A '+' is not part of a token, and a decimal point followed
+// by a digit that precedes any digits results in "0" (e.g. "v.5" results in
+// "0"). The token is not parsed, so it may not fit within an int64.
+// ErrDataUnparsable is returned if s holds no such token.
+func FindFirstInt(s string) (string, error) {
+	tok, ok := firstIntFromString(s)
+	if !ok {
+		return "", ErrDataUnparsable
+	}
+
+	return tok, nil
+}
+
+// FindFirstFloat returns the first float token within s using the same rules
+// that Segment applies to a path segment when scanning for a float: the token
+// begins at the first ASCII digit or decimal point, including a directly
+// preceding '-', or a '+' followed by a digit or decimal point, and surrounding
+// data is skipped (e.g. "w1.5e3kg" results in "1.5e3"). The token holds at
+// most one decimal point, which must precede any exponent, and at most one
+// exponent, which requires digits and may be signed (e.g. "1e3e4" results in
+// "1e3", and "6e" results in "6"). ErrDataUnparsable is returned if s holds no
+// such token.
+func FindFirstFloat(s string) (string, error) {
+	tok, ok := firstFloatFromString(s)
+	if !ok || !strings.ContainsAny(tok, "0123456789") {
+		return "", ErrDataUnparsable
+	}
+
+	return tok, nil
+}
+
+// FirstInt scans the path segments from first to last and returns the first
+// integer found within any of them along with the index of the segment that
+// contains it. Segments are handled in the same manner as with Segment, so
+// surrounding data is skipped (e.g. "/users/id42/7" results in 42 and 1). A
+// '-' that does not precede a digit is skipped as data, so a hyphenated segment
+// holds no integer (e.g. "/x-y/42" results in 42 and 1). An error is returned
+// if: 1. No segment contains an integer; 2. The integer found does not fit
+// within an int64.
+func FirstInt(path string) (int64, int, error) {
+	var s string
+	i := -1
+	eachSeg(path, func(n int, seg string) bool {
+		var ok bool
+		if s, ok = firstIntFromString(seg); ok {
+			i = n
+		}
+		return !ok
+	})
+	if i < 0 {
+		return 0, -1, ErrDataUnparsable
+	}
+
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, -1, newParseError(i, s, err)
+	}
+
+	return v, i, nil
+}
+
+// LastFloat scans the path segments from last to first and returns the last
+// float found within any of them along with the index of the segment that
+// contains it. If a segment contains multiple floats, the last is used (e.g.
+// "/w/1.5/2.5x3.5/units" results in 3.5 and 2). As with NumberLast, a '-' that
+// directly follows an ASCII letter or digit is not a sign once a float has been
+// found within the segment. An error is returned if: 1. No segment contains a
+// float; 2. The float found is out of range of a float64.
+func LastFloat(path string) (float64, int, error) {
+	n := segCount(path) - 1
+	for end := len(path); n >= 0; n-- {
+		b := strings.LastIndexByte(path[:end], '/')
+		s, ok := floatFromString(path[b+1:end], NumberLast)
+		if ok {
+			v, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return 0.0, -1, newParseError(n, s, err)
+			}
+
+			return v, n, nil
+		}
+
+		end = b
+	}
+
+	return 0.0, -1, ErrDataUnparsable
+}
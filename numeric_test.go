@@ -0,0 +1,714 @@
+package parth
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBhvrSegmentToFloat64Locale(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		sep  rune
+		want float64
+		ck   checkFunc
+	}{
+		{"comma", "/price/3,14/", 1, ',', 3.14, unx},
+		{"comma second stops", "/price/3,14,15/", 1, ',', 3.14, unx},
+		{"comma leading", "/price/,5/", 1, ',', 0.5, unx},
+		{"comma ignores period", "/price/3.14/", 1, ',', 3, unx},
+		{"comma exponent", "/price/1,5e+2/", 1, ',', 150, unx},
+		{"period", "/price/3.14/", 1, '.', 3.14, unx},
+		{"lone sep", "/price/,/", 1, ',', 0, exp},
+		{"non-ascii sep", "/price/3٫14/", 1, '٫', 0, exp},
+		{"bad index", "/price/3,14/", 3, ',', 0, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToFloat64Locale(tt.path, tt.i, tt.sep)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToNumber(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want interface{}
+		ck   checkFunc
+	}{
+		{"int", "/n/42", 1, int64(42), unx},
+		{"neg int", "/n/-42x", 1, int64(-42), unx},
+		{"float", "/n/3.14", 1, 3.14, unx},
+		{"exponent", "/n/1e3", 1, 1000.0, unx},
+		{"leading noise", "/n/v2.5", 1, 2.5, unx},
+		{"none", "/n/abc", 1, nil, exp},
+		{"overflow", "/n/99999999999999999999", 1, nil, exp},
+		{"bad index", "/n/42", 2, nil, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToNumber(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, fmt.Sprintf("%v (%T)", got, got), fmt.Sprintf("%v (%T)", tt.want, tt.want))
+		}
+	}
+}
+
+func TestBhvrSegmentToIntWithUnit(t *testing.T) {
+	units := map[string]int64{
+		"B":  1,
+		"KB": 1 << 10,
+		"MB": 1 << 20,
+		"s":  1,
+		"m":  60,
+	}
+
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want int64
+		ck   checkFunc
+	}{
+		{"megabytes", "/limit/10MB/", 1, 10 << 20, unx},
+		{"bytes", "/limit/10B/", 1, 10, unx},
+		{"minutes", "/ttl/30m/", 1, 1800, unx},
+		{"negative", "/ttl/-2m/", 1, -120, unx},
+		{"bare", "/ttl/30/", 1, 30, unx},
+		{"unknown unit", "/ttl/30h/", 1, 30, unx},
+		{"unit only", "/ttl/MB/", 1, 0, exp},
+		{"bad number", "/ttl/xs/", 1, 0, exp},
+		{"overflow", "/limit/9223372036854775807KB/", 1, 0, exp},
+		{"bad index", "/ttl/30s/", 2, 0, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToIntWithUnit(tt.path, tt.i, units)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToFloats(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want []float64
+		ck   checkFunc
+	}{
+		{"comma list", "/poly/1.5,2.5,3.0/", 1, []float64{1.5, 2.5, 3}, unx},
+		{"single", "/poly/1.5", 1, []float64{1.5}, unx},
+		{"noise", "/poly/x1.5y-2z.5", 1, []float64{1.5, -2, 0.5}, unx},
+		{"adjacent sign", "/poly/1-2", 1, []float64{1, -2}, unx},
+		{"second point", "/poly/1.2.3", 1, []float64{1.2, 0.3}, unx},
+		{"exponent", "/poly/1e3,2", 1, []float64{1000, 2}, unx},
+		{"lone tokens", "/poly/.,-,4", 1, []float64{4}, unx},
+		{"none", "/poly/abc", 1, nil, exp},
+		{"empty", "/poly//", 1, nil, exp},
+		{"out of range", "/poly/1e999", 1, nil, exp},
+		{"bad index", "/poly/1.5", 2, nil, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToFloats(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToIntExact(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want int64
+		ck   checkFunc
+	}{
+		{"int", "/id/42/", 1, 42, unx},
+		{"neg", "/id/-42", 1, -42, unx},
+		{"trailing noise", "/id/42x", 1, 0, exp},
+		{"leading noise", "/id/x42", 1, 0, exp},
+		{"float", "/id/4.2", 1, 0, exp},
+		{"empty", "/id//", 1, 0, exp},
+		{"overflow", "/id/9223372036854775808", 1, 0, exp},
+		{"bad index", "/id/42", 2, 0, exp},
+		{"negative", "/id/42", -1, 42, unx},
+		{"negative trailing slash", "/id/-42/", -1, -42, unx},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToIntExact(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToFloat64Exact(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want float64
+		ck   checkFunc
+	}{
+		{"float", "/v/4.2/", 1, 4.2, unx},
+		{"int", "/v/42", 1, 42, unx},
+		{"exponent", "/v/-1e3", 1, -1000, unx},
+		{"trailing noise", "/v/4.2x", 1, 0, exp},
+		{"leading noise", "/v/x4.2", 1, 0, exp},
+		{"empty", "/v//", 1, 0, exp},
+		{"bad index", "/v/4.2", 2, 0, exp},
+		{"nan", "/v/NaN", 1, 0, exp},
+		{"inf", "/v/-Inf", 1, 0, exp},
+		{"infinity", "/v/infinity", 1, 0, exp},
+		{"hex", "/v/0x1p3", 1, 0, exp},
+		{"underscore", "/v/1_000.5", 1, 0, exp},
+		{"negative", "/v/4.2", -1, 4.2, unx},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToFloat64Exact(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("special", func(t *testing.T) {
+		_, err := SegmentToFloat64Exact("/v/NaN", 1)
+		if !errors.Is(err, ErrDataUnparsable) {
+			t.Errorf(gwFmt, err, ErrDataUnparsable)
+		}
+	})
+}
+
+func TestBhvrSpanToComplex128(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		re, im int
+		want   complex128
+		ck     checkFunc
+	}{
+		{"ints", "/point/3/4/", 1, 2, complex(3, 4), unx},
+		{"floats", "/point/-1.5/2.5", 1, 2, complex(-1.5, 2.5), unx},
+		{"swapped", "/point/3/4", 2, 1, complex(4, 3), unx},
+		{"bad real", "/point/x/4", 1, 2, 0, exp},
+		{"bad imag", "/point/3/y", 1, 2, 0, exp},
+		{"missing imag", "/point/3", 1, 2, 0, exp},
+		{"negative", "/point/3/4", -2, -1, complex(3, 4), unx},
+		{"negative trailing slash", "/point/3/4/", -2, -1, complex(3, 4), unx},
+		{"negative out of range", "/3/4", -3, -1, 0, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SpanToComplex128(tt.path, tt.re, tt.im)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("message", func(t *testing.T) {
+		_, err := SpanToComplex128("/point/3/y", 1, 2)
+		if !errors.Is(err, ErrDataUnparsable) {
+			t.Fatalf(gwFmt, err, ErrDataUnparsable)
+		}
+
+		want := "imaginary part (segment 2): data cannot be parsed"
+		if err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+	})
+}
+
+func TestBhvrSegmentToIntDecoded(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want int64
+		ck   checkFunc
+	}{
+		{"encoded sign", "/price/%2D5/", 1, -5, unx},
+		{"plain", "/price/5", 1, 5, unx},
+		{"encoded noise", "/price/%20id%3D7", 1, 7, unx},
+		{"bad escape", "/price/%2G5", 1, 0, exp},
+		{"no int", "/price/%2D", 1, 0, exp},
+		{"bad index", "/price/5", 2, 0, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToIntDecoded(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToFloat64Decoded(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want float64
+		ck   checkFunc
+	}{
+		{"encoded sign", "/price/%2D5.5/", 1, -5.5, unx},
+		{"encoded point", "/price/5%2E25", 1, 5.25, unx},
+		{"plain", "/price/5.5", 1, 5.5, unx},
+		{"bad escape", "/price/5%", 1, 0, exp},
+		{"no float", "/price/%2E", 1, 0, exp},
+		{"bad index", "/price/5.5", 2, 0, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToFloat64Decoded(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToIntNoSign(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want int64
+		ck   checkFunc
+	}{
+		{"separator", "/n/a-5", 1, 5, unx},
+		{"plain", "/n/5", 1, 5, unx},
+		{"trailing hyphen", "/n/5-", 1, 5, unx},
+		{"leading hyphen", "/n/-5", 1, 0, exp},
+		{"no int", "/n/a-b", 1, 0, exp},
+		{"overflow", "/n/9223372036854775808", 1, 0, exp},
+		{"bad index", "/n/5", 2, 0, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToIntNoSign(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrFirstInt(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		want  int64
+		wantI int
+		ck    checkFunc
+	}{
+		{"first seg", "/42/users/7", 42, 0, unx},
+		{"middle seg", "/users/7/posts/9", 7, 1, unx},
+		{"noise", "/users/id-42/7", -42, 1, unx},
+		{"hyphenated seg", "/x-y/42", 42, 1, unx},
+		{"trailing hyphen seg", "/a-/7", 7, 1, unx},
+		{"hyphen before digits", "/a-b5/7", 5, 0, unx},
+		{"last seg", "/users/posts/9/", 9, 2, unx},
+		{"no leading slash", "users/3", 3, 1, unx},
+		{"none", "/users/posts", 0, -1, exp},
+		{"empty", "", 0, -1, exp},
+		{"overflow", "/a/99999999999999999999", 0, -1, exp},
+	}
+
+	for _, tt := range tests {
+		got, gotI, err := FirstInt(tt.path)
+		if gotI != tt.wantI {
+			t.Errorf(gwxFmt, tt.name, gotI, tt.wantI)
+		}
+
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrLastFloat(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		want  float64
+		wantI int
+		ck    checkFunc
+	}{
+		{"last seg", "/w/1.5/2.5", 2.5, 2, unx},
+		{"middle seg", "/w/1.5/2.5x3.5/units", 3.5, 2, unx},
+		{"first seg", "/4.25/units/kg", 4.25, 0, unx},
+		{"trailing slash", "/w/1.5/", 1.5, 1, unx},
+		{"no leading slash", "w/0.5", 0.5, 1, unx},
+		{"int", "/w/7/kg", 7, 1, unx},
+		{"none", "/w/units", 0, -1, exp},
+		{"empty", "", 0, -1, exp},
+		{"out of range", "/w/1" + strings.Repeat("0", 400), 0, -1, exp},
+	}
+
+	for _, tt := range tests {
+		got, gotI, err := LastFloat(tt.path)
+		if gotI != tt.wantI {
+			t.Errorf(gwxFmt, tt.name, gotI, tt.wantI)
+		}
+
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToDurationUnit(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		unit time.Duration
+		want time.Duration
+		ck   checkFunc
+	}{
+		{"bare seconds", "/timeout/30/", 1, time.Second, 30 * time.Second, unx},
+		{"bare millis", "/timeout/250", 1, time.Millisecond, 250 * time.Millisecond, unx},
+		{"bare noise", "/timeout/t30/", 1, time.Minute, 30 * time.Minute, unx},
+		{"duration string", "/timeout/1m30s", 1, time.Hour, 90 * time.Second, unx},
+		{"duration fraction", "/timeout/1.5h", 1, time.Second, 90 * time.Minute, unx},
+		{"zero", "/timeout/0", 1, time.Second, 0, unx},
+		{"negative", "/timeout/-5", 1, time.Second, -5 * time.Second, unx},
+		{"no number", "/timeout/never", 1, time.Second, 0, exp},
+		{"overflow", "/timeout/9223372036854775807", 1, time.Second, 0, exp},
+		{"overflow min count", "/timeout/-9223372036854775808", 1, -1, 0, exp},
+		{"overflow min unit", "/timeout/-1", 1, math.MinInt64, 0, exp},
+		{"bad index", "/timeout", 1, time.Second, 0, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToDurationUnit(tt.path, tt.i, tt.unit)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentsToIntSlice(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		indexes []int
+		want    []int64
+		ck      checkFunc
+	}{
+		{"spread", "/v/1/2/3/", []int{1, 2, 3}, []int64{1, 2, 3}, unx},
+		{"reordered", "/v/1/2/3/", []int{3, 1}, []int64{3, 1}, unx},
+		{"noise", "/id42/p-7", []int{0, 1}, []int64{42, -7}, unx},
+		{"none", "/v/1", nil, []int64{}, unx},
+		{"bad index", "/v/1/2", []int{1, 5}, nil, exp},
+		{"unparsable", "/v/1/x", []int{1, 2}, nil, exp},
+		{"negative", "/v/1/2/3", []int{-3, -2, -1}, []int64{1, 2, 3}, unx},
+		{"negative trailing slash", "/v/1/2/3/", []int{-1, -3}, []int64{3, 1}, unx},
+		{"negative out of range", "/v/1", []int{-3}, nil, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentsToIntSlice(tt.path, tt.indexes...)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("message", func(t *testing.T) {
+		_, err := SegmentsToIntSlice("/v/1/x", 1, 2)
+		if !errors.Is(err, ErrDataUnparsable) {
+			t.Fatalf(gwFmt, err, ErrDataUnparsable)
+		}
+
+		want := "position 1 (segment 2): data cannot be parsed"
+		if err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+	})
+}
+
+func TestBhvrSegmentToIntGrouped(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		sep  rune
+		want int64
+		ck   checkFunc
+	}{
+		{"comma", "/amount/1,000,000/", 1, ',', 1000000, unx},
+		{"period", "/amount/1.000.000", 1, '.', 1000000, unx},
+		{"ungrouped", "/amount/1234", 1, ',', 1234, unx},
+		{"negative", "/amount/-12,345", 1, ',', -12345, unx},
+		{"noise", "/amount/usd2,500each", 1, ',', 2500, unx},
+		{"irregular groups", "/amount/1,00,000", 1, ',', 100000, unx},
+		{"trailing sep", "/amount/1,000,", 1, ',', 1000, unx},
+		{"doubled sep", "/amount/1,,000", 1, ',', 1, unx},
+		{"other sep ignored", "/amount/1.000", 1, ',', 1, unx},
+		{"multi byte sep", "/amount/1\u202f000", 1, '\u202f', 1000, unx},
+		{"no int", "/amount/none", 1, ',', 0, exp},
+		{"digit sep", "/amount/1,000", 1, '0', 0, exp},
+		{"overflow", "/amount/9,223,372,036,854,775,808", 1, ',', 0, exp},
+		{"bad index", "/amount", 1, ',', 0, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToIntGrouped(tt.path, tt.i, tt.sep)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToIntStrictDigits(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want int64
+		ck   checkFunc
+	}{
+		{"digits", "/id/42", 1, 42, unx},
+		{"negative", "/id/-42", 1, -42, unx},
+		{"plus", "/id/+42", 1, 42, unx},
+		{"trailing space", "/id/42 ", 1, 42, unx},
+		{"trailing tab", "/id/42\t", 1, 42, unx},
+		{"decimal", "/id/42.0", 1, 0, exp},
+		{"inner space", "/id/4 2", 1, 0, exp},
+		{"leading space", "/id/ 42", 1, 0, exp},
+		{"noise", "/id/id42", 1, 0, exp},
+		{"sign only", "/id/-", 1, 0, exp},
+		{"empty", "/id//", 1, 0, exp},
+		{"overflow", "/id/9223372036854775808", 1, 0, exp},
+		{"bad index", "/id", 1, 0, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToIntStrictDigits(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToIntAuto(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want int64
+		ck   checkFunc
+	}{
+		{"hex", "/n/0xff", 1, 255, unx},
+		{"hex upper", "/n/0XFF", 1, 255, unx},
+		{"octal", "/n/0o17", 1, 15, unx},
+		{"binary", "/n/0b101", 1, 5, unx},
+		{"decimal", "/n/255", 1, 255, unx},
+		{"zero", "/n/0", 1, 0, unx},
+		{"bare leading zero", "/n/017", 1, 15, unx},
+		{"negative hex", "/n/-0x10", 1, -16, unx},
+		{"noise", "/n/id0x1Fz", 1, 31, unx},
+		{"binary stops", "/n/0b1012", 1, 5, unx},
+		{"bad prefix digit", "/n/0xg1", 1, 0, unx},
+		{"no int", "/n/none", 1, 0, exp},
+		{"overflow", "/n/0x8000000000000000", 1, 0, exp},
+		{"bad index", "/n", 1, 0, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToIntAuto(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToIntBaseBits(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		i       int
+		base    int
+		bitSize int
+		want    int64
+		ck      checkFunc
+	}{
+		{"hex int8", "/reg/7f/", 1, 16, 8, 127, unx},
+		{"hex upper", "/reg/7F", 1, 16, 64, 127, unx},
+		{"hex prefix skipped", "/reg/0x7f", 1, 16, 8, 127, unx},
+		{"negative hex", "/reg/-7f", 1, 16, 8, -127, unx},
+		{"negative hex prefix", "/reg/-0x7f", 1, 16, 8, -127, unx},
+		{"octal", "/mode/755", 1, 8, 16, 493, unx},
+		{"binary noise", "/b/x1010z", 1, 2, 8, 10, unx},
+		{"binary prefix", "/b/0b11", 1, 2, 8, 3, unx},
+		{"base 36", "/id/z", 1, 36, 64, 35, unx},
+		{"decimal stops", "/n/12ab", 1, 10, 64, 12, unx},
+		{"foreign prefix", "/n/0b1", 1, 16, 64, 177, unx},
+		{"auto", "/reg/0x7f", 1, 0, 8, 127, unx},
+		{"int8 overflow", "/reg/80", 1, 16, 8, 0, exp},
+		{"no digits", "/reg/zz", 1, 16, 8, 0, exp},
+		{"bad bit size", "/reg/7f", 1, 16, 65, 0, exp},
+		{"bad index", "/reg", 1, 16, 8, 0, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToIntBaseBits(tt.path, tt.i, tt.base, tt.bitSize)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrFindFirstInt(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+		ck   checkFunc
+	}{
+		{"plain", "42", "42", unx},
+		{"noise", "id-42x7", "-42", unx},
+		{"first of many", "a1b2", "1", unx},
+		{"plus skipped", "+7", "7", unx},
+		{"leading point", "v.5", "0", unx},
+		{"float", "3.14", "3", unx},
+		{"overflow kept", "99999999999999999999", "99999999999999999999", unx},
+		{"non-ascii digits", "\u0661\u0662", "", exp},
+		{"lone sign", "x-", "", exp},
+		{"sign before letter", "a-b5", "5", unx},
+		{"sign before space", "- 5", "5", unx},
+		{"none", "none", "", exp},
+		{"empty", "", "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := FindFirstInt(tt.s)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrFindFirstFloat(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+		ck   checkFunc
+	}{
+		{"plain", "1.5", "1.5", unx},
+		{"noise", "w1.5e3kg", "1.5e3", unx},
+		{"negative", "t=-0.25", "-0.25", unx},
+		{"plus", "x+.5", "+.5", unx},
+		{"leading point", ".75", ".75", unx},
+		{"second point", "1.2.3", "1.2", unx},
+		{"second exponent", "1e3e4", "1e3", unx},
+		{"dangling exponent", "6e", "6", unx},
+		{"int", "7", "7", unx},
+		{"lone point", ".", "", exp},
+		{"lone sign", "x-", "", exp},
+		{"none", "none", "", exp},
+		{"empty", "", "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := FindFirstFloat(tt.s)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package parth
+
+// Optional holds a value that may be absent. The zero value is absent.
+type Optional[T any] struct {
+	v  T
+	ok bool
+}
+
+// Value returns the held value and whether it is present. If it is absent,
+// the zero value of T is returned.
+func (o Optional[T]) Value() (T, bool) {
+	return o.v, o.ok
+}
+
+// SegmentOpt locates the path segment indicated by the index i and unmarshals
+// it into a T in the same manner as Segment. The result is present only if
+// Segment would succeed; an absent or unparsable segment, or a T that Segment
+// does not support, results in an absent Optional. Use Segment directly when
+// the cause of absence matters.
+func SegmentOpt[T any](path string, i int) Optional[T] {
+	var v T
+	if err := Segment(path, i, &v); err != nil {
+		return Optional[T]{}
+	}
+
+	return Optional[T]{v: v, ok: true}
+}
@@ -0,0 +1,56 @@
+package parth
+
+import "testing"
+
+func TestBhvrSegmentOpt(t *testing.T) {
+	path := "/users/7/active/true/name/bob"
+
+	t.Run("int", func(t *testing.T) {
+		got, ok := SegmentOpt[int](path, 1).Value()
+		if !ok || got != 7 {
+			t.Errorf(gwFmt, got, 7)
+		}
+	})
+
+	t.Run("bool", func(t *testing.T) {
+		got, ok := SegmentOpt[bool](path, 3).Value()
+		if !ok || !got {
+			t.Errorf(gwFmt, got, true)
+		}
+	})
+
+	t.Run("string", func(t *testing.T) {
+		got, ok := SegmentOpt[string](path, 5).Value()
+		if !ok || got != "bob" {
+			t.Errorf(gwFmt, got, "bob")
+		}
+	})
+
+	t.Run("absent", func(t *testing.T) {
+		got, ok := SegmentOpt[int](path, 9).Value()
+		if ok || got != 0 {
+			t.Errorf(gwFmt, got, 0)
+		}
+	})
+
+	t.Run("unparsable", func(t *testing.T) {
+		got, ok := SegmentOpt[float64](path, 0).Value()
+		if ok || got != 0 {
+			t.Errorf(gwFmt, got, 0)
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		got, ok := SegmentOpt[uintptr](path, 1).Value()
+		if ok || got != 0 {
+			t.Errorf(gwFmt, got, 0)
+		}
+	})
+
+	t.Run("zero value", func(t *testing.T) {
+		var o Optional[string]
+		if got, ok := o.Value(); ok || got != "" {
+			t.Errorf(gwFmt, got, "")
+		}
+	})
+}
@@ -5,11 +5,19 @@
 // Along with string, all basic non-alias types are supported. An interface is
 // available for implementation by user-defined types. When handling an int,
 // uint, or float of any size, the first valid value within the specified
-// segment will be used.
+// segment will be used. Only ASCII digits are recognized as part of a value;
+// non-ASCII digits (e.g. Arabic-Indic digits) and other multibyte runes are
+// treated as non-numeric data.
+//
+// A float value may include an exponent, written as "e" or "E" followed by an
+// optional sign and at least one digit (e.g. "1e3" or "2.5E-3"). An exponent
+// marker that is not followed by a digit ends the value (e.g. "2.5e-x" results
+// in 2.5).
 package parth
 
 import (
 	"errors"
+	"fmt"
 )
 
 // Unmarshaler is the interface implemented by types that can unmarshal a path
@@ -26,19 +34,87 @@ var (
 	ErrFirstSegNotFound = errors.New("first segment not found by index")
 	ErrLastSegNotFound  = errors.New("last segment not found by index")
 	ErrSegOrderReversed = errors.New("first segment must precede last segment")
+	ErrSegCountInvalid  = errors.New("segment count is invalid")
 	ErrKeySegNotFound   = errors.New("segment not found by key")
+	ErrSegTooLong       = errors.New("segment exceeds length limit")
+	ErrSegWrongLen      = errors.New("segment is not the required length")
+	ErrEmptySegment     = errors.New("segment is empty")
+	ErrNotAbsolute      = errors.New("path does not begin with a slash")
 
 	ErrDataUnparsable = errors.New("data cannot be parsed")
+	ErrUnknownEnum    = errors.New("unknown enum value")
+
+	ErrBadPattern    = errors.New("syntax error in pattern")
+	ErrShapeMismatch = errors.New("path does not match shape")
+)
+
+// NumberMode values select which value is used when a segment is unmarshaled
+// into an int, uint, or float of any size.
+type NumberMode int
+
+// NumberMode values.
+const (
+	// NumberFirst selects the first valid value within a segment.
+	NumberFirst NumberMode = iota
+	// NumberLast selects the last valid value within a segment. Once a value
+	// has been found, a '-' that directly follows an ASCII letter or digit
+	// separates values rather than signing the next (e.g. "v1-build-42"
+	// results in 42, not -42, while "x-5" results in -5). Integers do not
+	// treat the fractional digits of a decimal as a separate value (e.g.
+	// "ver1.25" results in 1).
+	NumberLast
 )
 
+// ParseError records a failure to parse the numeric data found within a path
+// segment. It matches ErrDataUnparsable when used with errors.Is, and the
+// underlying error (e.g. a *strconv.NumError) is available via errors.As.
+type ParseError struct {
+	Index int    // index provided to the failed call
+	Token string // data that could not be parsed
+	Err   error  // underlying error
+}
+
+func newParseError(i int, token string, err error) *ParseError {
+	return &ParseError{Index: i, Token: token, Err: err}
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("segment %d: cannot parse %q: %v", e.Index, e.Token, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrDataUnparsable.
+func (e *ParseError) Is(target error) bool {
+	return target == ErrDataUnparsable
+}
+
 // Segment locates the path segment indicated by the index i and unmarshals it
 // into the provided type v. If the index is negative, the negative count
-// begins with the last segment. An error is returned if: 1. The type is not a
+// begins with the last segment and a single trailing slash is ignored (e.g. -1
+// locates "b" within both "/a/b" and "/a/b/"). An error is returned if: 1. The type is not a
 // pointer to an instance of one of the basic non-alias types and does not
 // implement the Unmarshaler interface; 2. The index is out of range of the
 // path; 3. The located path segment data cannot be parsed as the provided type
 // or if an error is returned when using a provided Unmarshaler implementation.
-func Segment(path string, i int, v interface{}) error { //nolint
+// Numeric data that is found but cannot be parsed (e.g. due to overflow) is
+// reported as a *ParseError.
+func Segment(path string, i int, v interface{}) error {
+	return segment(path, i, v, NumberFirst)
+}
+
+// SegmentStable is equivalent to Segment. It was introduced while Segment did
+// not ignore a trailing slash for negative indexes and remains available for
+// existing callers.
+func SegmentStable(path string, i int, v interface{}) error {
+	return Segment(path, i, v)
+}
+
+func segment(path string, i int, v interface{}, m NumberMode) error { //nolint
 	var err error
 
 	switch v := v.(type) {
@@ -47,33 +123,33 @@ func Segment(path string, i int, v interface{}) error { //nolint
 
 	case *float32:
 		var f float64
-		f, err = segmentToFloatN(path, i, 32)
+		f, err = segmentToFloatN(path, i, 32, m)
 		*v = float32(f)
 
 	case *float64:
-		*v, err = segmentToFloatN(path, i, 64)
+		*v, err = segmentToFloatN(path, i, 64, m)
 
 	case *int:
 		var n int64
-		n, err = segmentToIntN(path, i, 0)
+		n, err = segmentToIntN(path, i, 0, m)
 		*v = int(n)
 
 	case *int16:
 		var n int64
-		n, err = segmentToIntN(path, i, 16)
+		n, err = segmentToIntN(path, i, 16, m)
 		*v = int16(n)
 
 	case *int32:
 		var n int64
-		n, err = segmentToIntN(path, i, 32)
+		n, err = segmentToIntN(path, i, 32, m)
 		*v = int32(n)
 
 	case *int64:
-		*v, err = segmentToIntN(path, i, 64)
+		*v, err = segmentToIntN(path, i, 64, m)
 
 	case *int8:
 		var n int64
-		n, err = segmentToIntN(path, i, 8)
+		n, err = segmentToIntN(path, i, 8, m)
 		*v = int8(n)
 
 	case *string:
@@ -81,25 +157,25 @@ func Segment(path string, i int, v interface{}) error { //nolint
 
 	case *uint:
 		var n uint64
-		n, err = segmentToUintN(path, i, 0)
+		n, err = segmentToUintN(path, i, 0, m)
 		*v = uint(n)
 
 	case *uint16:
 		var n uint64
-		n, err = segmentToUintN(path, i, 16)
+		n, err = segmentToUintN(path, i, 16, m)
 		*v = uint16(n)
 
 	case *uint32:
 		var n uint64
-		n, err = segmentToUintN(path, i, 32)
+		n, err = segmentToUintN(path, i, 32, m)
 		*v = uint32(n)
 
 	case *uint64:
-		*v, err = segmentToUintN(path, i, 64)
+		*v, err = segmentToUintN(path, i, 64, m)
 
 	case *uint8:
 		var n uint64
-		n, err = segmentToUintN(path, i, 8)
+		n, err = segmentToUintN(path, i, 8, m)
 		*v = uint8(n)
 
 	case Unmarshaler:
@@ -129,8 +205,31 @@ func Sequent(path, key string, v interface{}) error {
 // acts as an alias for the end of the path. If the first segment does not begin
 // with a slash and it is part of the requested span, no slash will be added. An
 // error is returned if: 1. Either index is out of range of the path; 2. The
-// first index i does not precede the last index j.
+// first index i does not precede the last index j. Out of range errors wrap
+// ErrFirstSegNotFound or ErrLastSegNotFound and name the offending index.
 func Span(path string, i, j int) (string, error) {
+	s, err := span(path, i, j)
+	if err != nil {
+		return "", spanError(err, i, j)
+	}
+
+	return s, nil
+}
+
+// spanError adds the offending index to an out of range error returned by
+// span. The provided indexes should be those given by the caller.
+func spanError(err error, i, j int) error {
+	switch err {
+	case ErrFirstSegNotFound:
+		return fmt.Errorf("%w: segment index %d does not exist", err, i)
+	case ErrLastSegNotFound:
+		return fmt.Errorf("%w: segment index %d does not exist", err, j)
+	}
+
+	return err
+}
+
+func span(path string, i, j int) (string, error) {
 	var f, l int
 	var ok bool
 
@@ -143,7 +242,11 @@ func Span(path string, i, j int) (string, error) {
 		return "", ErrFirstSegNotFound
 	}
 
-	if j > 0 {
+	if j > 0 && i >= 0 && j > i {
+		// resume scanning from the first index rather than the path start
+		l, ok = segEndIndexFromStart(path[f:], j-i)
+		l += f
+	} else if j > 0 {
 		l, ok = segEndIndexFromStart(path, j)
 	} else {
 		l, ok = segEndIndexFromEnd(path, j)
@@ -167,7 +270,11 @@ func Span(path string, i, j int) (string, error) {
 // subsequent to the provided key. For example, to access the segment
 // immediately after a key, an index of 0 should be provided (see Sequent). An
 // error is returned if the key cannot be found in the path.
-func SubSeg(path, key string, i int, v interface{}) error { //nolint
+func SubSeg(path, key string, i int, v interface{}) error {
+	return subSeg(path, key, i, v, NumberFirst)
+}
+
+func subSeg(path, key string, i int, v interface{}, m NumberMode) error { //nolint
 	var err error
 
 	switch v := v.(type) {
@@ -176,33 +283,33 @@ func SubSeg(path, key string, i int, v interface{}) error { //nolint
 
 	case *float32:
 		var f float64
-		f, err = subSegToFloatN(path, key, i, 32)
+		f, err = subSegToFloatN(path, key, i, 32, m)
 		*v = float32(f)
 
 	case *float64:
-		*v, err = subSegToFloatN(path, key, i, 64)
+		*v, err = subSegToFloatN(path, key, i, 64, m)
 
 	case *int:
 		var n int64
-		n, err = subSegToIntN(path, key, i, 0)
+		n, err = subSegToIntN(path, key, i, 0, m)
 		*v = int(n)
 
 	case *int16:
 		var n int64
-		n, err = subSegToIntN(path, key, i, 16)
+		n, err = subSegToIntN(path, key, i, 16, m)
 		*v = int16(n)
 
 	case *int32:
 		var n int64
-		n, err = subSegToIntN(path, key, i, 32)
+		n, err = subSegToIntN(path, key, i, 32, m)
 		*v = int32(n)
 
 	case *int64:
-		*v, err = subSegToIntN(path, key, i, 64)
+		*v, err = subSegToIntN(path, key, i, 64, m)
 
 	case *int8:
 		var n int64
-		n, err = subSegToIntN(path, key, i, 8)
+		n, err = subSegToIntN(path, key, i, 8, m)
 		*v = int8(n)
 
 	case *string:
@@ -210,25 +317,25 @@ func SubSeg(path, key string, i int, v interface{}) error { //nolint
 
 	case *uint:
 		var n uint64
-		n, err = subSegToUintN(path, key, i, 0)
+		n, err = subSegToUintN(path, key, i, 0, m)
 		*v = uint(n)
 
 	case *uint16:
 		var n uint64
-		n, err = subSegToUintN(path, key, i, 16)
+		n, err = subSegToUintN(path, key, i, 16, m)
 		*v = uint16(n)
 
 	case *uint32:
 		var n uint64
-		n, err = subSegToUintN(path, key, i, 32)
+		n, err = subSegToUintN(path, key, i, 32, m)
 		*v = uint32(n)
 
 	case *uint64:
-		*v, err = subSegToUintN(path, key, i, 64)
+		*v, err = subSegToUintN(path, key, i, 64, m)
 
 	case *uint8:
 		var n uint64
-		n, err = subSegToUintN(path, key, i, 8)
+		n, err = subSegToUintN(path, key, i, 8, m)
 		*v = uint8(n)
 
 	case Unmarshaler:
@@ -254,16 +361,17 @@ func SubSpan(path, key string, i, j int) (string, error) {
 		return "", ErrKeySegNotFound
 	}
 
-	if i >= 0 {
-		i++
+	f, l := i, j
+	if f >= 0 {
+		f++
 	}
-	if j > 0 {
-		j++
+	if l > 0 {
+		l++
 	}
 
-	s, err := Span(path[si:], i, j)
+	s, err := span(path[si:], f, l)
 	if err != nil {
-		return "", err
+		return "", spanError(err, i, j)
 	}
 
 	return s, nil
@@ -275,25 +383,49 @@ func SubSpan(path, key string, i, j int) (string, error) {
 type Parth struct {
 	path string
 	err  error
+	idxs []int
+	mode NumberMode
 }
 
 // New constructs a pointer to an instance of Parth around the provided path.
+// The segment indexes of the path are cached up front so that positive index
+// segment lookups do not rescan the path.
 func New(path string) *Parth {
-	return &Parth{path: path}
+	return &Parth{path: path, idxs: PathIndexes(path)}
 }
 
 // NewBySpan constructs a pointer to an instance of Parth after preprocessing
 // the provided path with Span.
 func NewBySpan(path string, i, j int) *Parth {
 	s, err := Span(path, i, j)
-	return &Parth{s, err}
+	return &Parth{path: s, err: err, idxs: PathIndexes(s)}
 }
 
 // NewBySubSpan constructs a pointer to an instance of Parth after
 // preprocessing the provided path with SubSpan.
 func NewBySubSpan(path, key string, i, j int) *Parth {
 	s, err := SubSpan(path, key, i, j)
-	return &Parth{s, err}
+	return &Parth{path: s, err: err, idxs: PathIndexes(s)}
+}
+
+// WithNumberMode sets the NumberMode used by the *Parth receiver when
+// unmarshaling ints, uints, and floats, and returns the receiver. The default
+// mode is NumberFirst.
+func (p *Parth) WithNumberMode(m NumberMode) *Parth {
+	p.mode = m
+	return p
+}
+
+// WithStrictAbsolute requires that the path of the *Parth receiver begins with
+// a slash, and returns the receiver. If it does not, ErrNotAbsolute is stored
+// as the first error so that all subsequent access is elided. By default, a
+// path without a leading slash is processed as though its first segment is
+// preceded by one.
+func (p *Parth) WithStrictAbsolute() *Parth {
+	if p.err == nil && (p.path == "" || p.path[0] != '/') {
+		p.err = ErrNotAbsolute
+	}
+	return p
 }
 
 // Err returns the first error encountered by the *Parth receiver.
@@ -301,13 +433,43 @@ func (p *Parth) Err() error {
 	return p.err
 }
 
+// Path returns the path managed by the *Parth receiver. For instances
+// constructed by NewBySpan or NewBySubSpan, this is the preprocessed path.
+func (p *Parth) Path() string {
+	return p.path
+}
+
+// Len returns the number of segments in the path managed by the *Parth
+// receiver, as counted by the non-negative indexing of Segment (i.e. with "/"
+// holding a single empty segment, and a trailing slash holding an empty final
+// segment). Unlike TotalSegments, a trailing slash is therefore counted (e.g.
+// "/a/b/c/" results in 4 rather than 3). The count is taken from the segment
+// indexes cached at construction, so the path is not rescanned.
+func (p *Parth) Len() int {
+	if len(p.idxs) == 0 {
+		return 0
+	}
+
+	return len(p.idxs) - 1
+}
+
 // Segment operates the same as the package-level function Segment.
 func (p *Parth) Segment(i int, v interface{}) {
 	if p.err != nil {
 		return
 	}
 
-	p.err = Segment(p.path, i, v)
+	if i >= 0 && i+1 < len(p.idxs) {
+		err := segment(p.path[p.idxs[i]:p.idxs[i+1]], 0, v, p.mode)
+		if pe, ok := err.(*ParseError); ok {
+			pe.Index = i
+		}
+
+		p.err = err
+		return
+	}
+
+	p.err = segment(p.path, i, v, p.mode)
 }
 
 // Sequent operates the same as the package-level function Sequent.
@@ -333,7 +495,7 @@ func (p *Parth) SubSeg(key string, i int, v interface{}) {
 		return
 	}
 
-	p.err = SubSeg(p.path, key, i, v)
+	p.err = subSeg(p.path, key, i, v, p.mode)
 }
 
 // SubSpan operates the same as the package-level function SubSpan.
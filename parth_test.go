@@ -1,8 +1,11 @@
 package parth
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"reflect"
+	"strconv"
 	"testing"
 )
 
@@ -31,6 +34,153 @@ func TestBhvrSegment(t *testing.T) {
 		err := Segment(path, 3, &x)
 		exp(t, t.Name(), err)
 	})
+
+	t.Run("negative", func(t *testing.T) {
+		tests := []struct {
+			name string
+			path string
+			i    int
+			want string
+			ck   checkFunc
+		}{
+			{"last", "/a/b", -1, "b", unx},
+			{"last trailing slash", "/a/b/", -1, "b", unx},
+			{"second last", "/a/b/c", -2, "b", unx},
+			{"first by negative", "/a/b/c", -3, "a", unx},
+			{"single", "/a", -1, "a", unx},
+			{"out of range", "/a/b", -3, "", exp},
+			{"empty path", "", -1, "", exp},
+		}
+
+		for _, tt := range tests {
+			var got string
+			err := Segment(tt.path, tt.i, &got)
+			if tt.ck(t, tt.name, err) {
+				continue
+			}
+
+			if got != tt.want {
+				t.Errorf(gwxFmt, tt.name, got, tt.want)
+			}
+		}
+	})
+
+	t.Run("multibyte", func(t *testing.T) {
+		var n int
+		err := Segment("/café/١٢٣", 1, &n)
+		exp(t, t.Name(), err)
+
+		err = Segment("/café/١٢٣", 0, &n)
+		exp(t, t.Name(), err)
+	})
+
+	t.Run("loneHyphen", func(t *testing.T) {
+		var n int
+		err := Segment("/a-b5", 0, &n)
+		if unx(t, t.Name(), err) {
+			return
+		}
+
+		if n != 5 {
+			t.Errorf(gwxFmt, t.Name(), n, 5)
+		}
+	})
+
+	t.Run("signedZero", func(t *testing.T) {
+		tests := []struct {
+			path    string
+			signbit bool
+		}{
+			{"/v/-0.0/x", true},
+			{"/v/-0/x", true},
+			{"/v/-0e0/x", true},
+			{"/v/+0.0/x", false},
+			{"/v/0e0/x", false},
+			{"/v/0.0/x", false},
+		}
+
+		for _, tt := range tests {
+			var f64 float64
+			err := Segment(tt.path, 1, &f64)
+			if unx(t, tt.path, err) {
+				continue
+			}
+
+			if f64 != 0 || math.Signbit(f64) != tt.signbit {
+				t.Errorf(gwxFmt, tt.path, f64, tt.signbit)
+			}
+
+			var f32 float32
+			err = Segment(tt.path, 1, &f32)
+			if unx(t, tt.path, err) {
+				continue
+			}
+
+			if f32 != 0 || math.Signbit(float64(f32)) != tt.signbit {
+				t.Errorf(gwxFmt, tt.path, f32, tt.signbit)
+			}
+		}
+	})
+
+	t.Run("intAllocs", func(t *testing.T) {
+		for _, p := range []string{"/zero/42", "/zero/id-42x", "/zero/.7"} {
+			var v int64
+			n := testing.AllocsPerRun(10, func() {
+				_ = Segment(p, 1, &v)
+			})
+			if n != 0 {
+				t.Errorf(gwxFmt, p, n, 0)
+			}
+		}
+	})
+
+	t.Run("noFloat", func(t *testing.T) {
+		var f float64
+		err := Segment(path, 0, &f)
+		exp(t, t.Name(), err)
+	})
+}
+
+func TestBhvrSegmentStable(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"last", "/a/b", -1, "b", unx},
+		{"last trailing slash", "/a/b/", -1, "b", unx},
+		{"second last", "/a/b", -2, "a", unx},
+		{"second last trailing slash", "/a/b/", -2, "a", unx},
+		{"no leading slash", "a/b/", -1, "b", unx},
+		{"double trailing slash", "/a/b//", -1, "", unx},
+		{"positive literal", "/a/b/", 2, "", unx},
+		{"out of range", "/a/b/", -3, "", exp},
+		{"empty path", "", -1, "", exp},
+	}
+
+	for _, tt := range tests {
+		var got string
+		err := SegmentStable(tt.path, tt.i, &got)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("int", func(t *testing.T) {
+		var got int
+		err := SegmentStable("/items/42/", -1, &got)
+		unx(t, t.Name(), err)
+
+		if got != 42 {
+			t.Errorf(gwFmt, got, 42)
+		}
+	})
 }
 
 func TestBhvrSequent(t *testing.T) {
@@ -78,6 +228,11 @@ func TestBhvrSpan(t *testing.T) {
 		{"5 segs: -9,00", path, -9, 0, "", exp},
 		{"5 segs: 00,+9", path, 0, 9, "", exp},
 		{"3 no /: 00,+9", "zero/one/two", 0, 2, "zero/one", unx},
+		{"3 no /: +1,+3", "zero/one/two", 1, 3, "/one/two", unx},
+		{"3 trailing /: +2,+4", "/zero/one/two/", 2, 4, "/two/", unx},
+		{"5 segs: +3,+3", path, 3, 3, "", unx},
+		{"5 segs: +3,+2", path, 3, 2, "", exp},
+		{"5 segs: +3,+6", path, 3, 6, "", exp},
 	}
 
 	for _, tt := range tests {
@@ -92,6 +247,40 @@ func TestBhvrSpan(t *testing.T) {
 	}
 }
 
+func TestBhvrSpanOutOfRange(t *testing.T) {
+	path := "/a/b/c"
+
+	tests := []struct {
+		name string
+		i, j int
+		want error
+		msg  string
+	}{
+		{"-5,-1", -5, -1, ErrFirstSegNotFound, "first segment not found by index: segment index -5 does not exist"},
+		{"-4,00", -4, 0, ErrFirstSegNotFound, "first segment not found by index: segment index -4 does not exist"},
+		{"+3,00", 3, 0, ErrFirstSegNotFound, "first segment not found by index: segment index 3 does not exist"},
+		{"+5,+6", 5, 6, ErrFirstSegNotFound, "first segment not found by index: segment index 5 does not exist"},
+		{"+0,-4", 0, -4, ErrLastSegNotFound, "last segment not found by index: segment index -4 does not exist"},
+		{"-1,-5", -1, -5, ErrLastSegNotFound, "last segment not found by index: segment index -5 does not exist"},
+		{"+1,+4", 1, 4, ErrLastSegNotFound, "last segment not found by index: segment index 4 does not exist"},
+		{"-2,+9", -2, 9, ErrLastSegNotFound, "last segment not found by index: segment index 9 does not exist"},
+		{"+2,+1", 2, 1, ErrSegOrderReversed, "first segment must precede last segment"},
+		{"-1,-2", -1, -2, ErrSegOrderReversed, "first segment must precede last segment"},
+	}
+
+	for _, tt := range tests {
+		_, err := Span(path, tt.i, tt.j)
+		if !errors.Is(err, tt.want) {
+			t.Errorf(gwxFmt, tt.name, err, tt.want)
+			continue
+		}
+
+		if err.Error() != tt.msg {
+			t.Errorf(gwxFmt, tt.name, err, tt.msg)
+		}
+	}
+}
+
 func TestBhvrSubSeg(t *testing.T) {
 	path := "/junk/4/key/true/other/3.3/"
 
@@ -150,6 +339,34 @@ func TestBhvrSubSpan(t *testing.T) {
 	}
 }
 
+func TestBhvrSubSpanOutOfRange(t *testing.T) {
+	path := "/a/key/b/c"
+
+	tests := []struct {
+		name string
+		i, j int
+		want error
+		msg  string
+	}{
+		{"+5,00", 5, 0, ErrFirstSegNotFound, "first segment not found by index: segment index 5 does not exist"},
+		{"-4,00", -4, 0, ErrFirstSegNotFound, "first segment not found by index: segment index -4 does not exist"},
+		{"+0,+3", 0, 3, ErrLastSegNotFound, "last segment not found by index: segment index 3 does not exist"},
+		{"+0,-4", 0, -4, ErrLastSegNotFound, "last segment not found by index: segment index -4 does not exist"},
+	}
+
+	for _, tt := range tests {
+		_, err := SubSpan(path, "key", tt.i, tt.j)
+		if !errors.Is(err, tt.want) {
+			t.Errorf(gwxFmt, tt.name, err, tt.want)
+			continue
+		}
+
+		if err.Error() != tt.msg {
+			t.Errorf(gwxFmt, tt.name, err, tt.msg)
+		}
+	}
+}
+
 func TestBhvrParth(t *testing.T) {
 	t.Run("bySpan/segment", func(t *testing.T) {
 		p := NewBySpan("/zero/one/two/three", 1, 3)
@@ -216,6 +433,393 @@ func TestBhvrParth(t *testing.T) {
 	})
 }
 
+func TestBhvrParseError(t *testing.T) {
+	path := "/junk/300/key/-1/other/1e999"
+
+	tests := []struct {
+		name string
+		fn   func() error
+		msg  string
+	}{
+		{"int8", func() error {
+			var v int8
+			return Segment(path, 1, &v)
+		}, `segment 1: cannot parse "300": strconv.ParseInt: parsing "300": value out of range`},
+		{"float64", func() error {
+			var v float64
+			return Segment(path, 5, &v)
+		}, `segment 5: cannot parse "1e999": strconv.ParseFloat: parsing "1e999": value out of range`},
+		{"subSeg uint8", func() error {
+			var v uint8
+			return SubSeg(path, "junk", 0, &v)
+		}, `segment 0: cannot parse "300": strconv.ParseUint: parsing "300": value out of range`},
+		{"intExact", func() error {
+			_, err := SegmentToIntExact("/id/9223372036854775808", 1)
+			return err
+		}, `segment 1: cannot parse "9223372036854775808": strconv.ParseInt: parsing "9223372036854775808": value out of range`},
+		{"intExact syntax", func() error {
+			_, err := SegmentToIntExact(path, 0)
+			return err
+		}, `segment 0: cannot parse "junk": strconv.ParseInt: parsing "junk": invalid syntax`},
+		{"pooled", func() error {
+			p := AcquireParth("/a/b/x99999999999999999999")
+			defer ReleaseParth(p)
+
+			var v int64
+			p.Segment(2, &v)
+			return p.Err()
+		}, `segment 2: cannot parse "99999999999999999999": strconv.ParseInt: parsing "99999999999999999999": value out of range`},
+		{"cached after len", func() error {
+			p := New(path)
+			p.Len()
+
+			var v int8
+			p.Segment(1, &v)
+			return p.Err()
+		}, `segment 1: cannot parse "300": strconv.ParseInt: parsing "300": value out of range`},
+	}
+
+	for _, tt := range tests {
+		err := tt.fn()
+		if !errors.Is(err, ErrDataUnparsable) {
+			t.Errorf(gwxFmt, tt.name, err, ErrDataUnparsable)
+			continue
+		}
+
+		var numErr *strconv.NumError
+		if !errors.As(err, &numErr) {
+			t.Errorf(gwxFmt, tt.name, err, "{*strconv.NumError}")
+			continue
+		}
+
+		if err.Error() != tt.msg {
+			t.Errorf(gwxFmt, tt.name, err, tt.msg)
+		}
+	}
+}
+
+func TestBhvrSegmentFloat32Range(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want float32
+		ck   checkFunc
+	}{
+		{"max", "/v/3.4028234e38", math.MaxFloat32, unx},
+		{"in range", "/v/3.4e38", 3.4e38, unx},
+		{"negative in range", "/v/-3.4e38", -3.4e38, unx},
+		{"just over", "/v/3.5e38", 0, exp},
+		{"over", "/v/3.5e39", 0, exp},
+		{"negative over", "/v/-3.5e39", 0, exp},
+	}
+
+	for _, tt := range tests {
+		var got float32
+		err := Segment(tt.path, 1, &got)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+
+		if err != nil && !errors.Is(err, ErrDataUnparsable) {
+			t.Errorf(gwxFmt, tt.name, err, ErrDataUnparsable)
+		}
+
+		got = 0
+		if err = SubSeg(tt.path, "v", 0, &got); (err == nil) != (tt.want != 0) || got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentSignedBounds(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		v    interface{}
+		want interface{}
+		ck   checkFunc
+	}{
+		{"int8 min", "/v/-128", new(int8), int8(math.MinInt8), unx},
+		{"int8 max", "/v/127", new(int8), int8(math.MaxInt8), unx},
+		{"int8 under", "/v/-129", new(int8), int8(0), exp},
+		{"int8 over", "/v/128", new(int8), int8(0), exp},
+		{"int8 noise min", "/v/x-128y", new(int8), int8(math.MinInt8), unx},
+		{"int16 min", "/v/-32768", new(int16), int16(math.MinInt16), unx},
+		{"int16 max", "/v/32767", new(int16), int16(math.MaxInt16), unx},
+		{"int16 under", "/v/-32769", new(int16), int16(0), exp},
+		{"int16 over", "/v/32768", new(int16), int16(0), exp},
+		{"int32 min", "/v/-2147483648", new(int32), int32(math.MinInt32), unx},
+		{"int32 max", "/v/2147483647", new(int32), int32(math.MaxInt32), unx},
+		{"int32 under", "/v/-2147483649", new(int32), int32(0), exp},
+		{"int32 over", "/v/2147483648", new(int32), int32(0), exp},
+	}
+
+	for _, tt := range tests {
+		err := Segment(tt.path, 1, tt.v)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if err != nil {
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Errorf(gwxFmt, tt.name, err, "{*ParseError}")
+			}
+		}
+
+		got := reflect.ValueOf(tt.v).Elem().Interface()
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentFloatPlusSign(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want float64
+	}{
+		{"plus", "/v/+1.5/x", 1.5},
+		{"plus point", "/v/+.5", 0.5},
+		{"plus exponent", "/v/+1e3", 1000},
+		{"mid-token plus", "/v/1+2", 1},
+	}
+
+	for _, tt := range tests {
+		var got float64
+		err := Segment(tt.path, 1, &got)
+		if unx(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentFloatMultipleExponents(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want float64
+	}{
+		{"second exponent", "/v/1e3e4/x", 1000},
+		{"second exponent upper", "/v/1E3E4", 1000},
+		{"second exponent after sign", "/v/1e-3e4", 0.001},
+		{"doubled marker", "/v/1ee3", 1},
+	}
+
+	for _, tt := range tests {
+		var got float64
+		err := Segment(tt.path, 1, &got)
+		if unx(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrParthWithNumberMode(t *testing.T) {
+	path := "/v1-build-42/2.5x7.25/key/a1b2"
+
+	tests := []struct {
+		name string
+		mode NumberMode
+		u    uint
+		n    int
+		f    float64
+		sub  int8
+	}{
+		{"first", NumberFirst, 1, 1, 2.5, 1},
+		{"last", NumberLast, 42, 42, 7.25, 2},
+	}
+
+	for _, tt := range tests {
+		p := New(path).WithNumberMode(tt.mode)
+
+		var u uint
+		var n int
+		var f float64
+		var sub int8
+		p.Segment(0, &u)
+		p.Segment(0, &n)
+		p.Segment(1, &f)
+		p.Sequent("key", &sub)
+		if unx(t, tt.name, p.Err()) {
+			continue
+		}
+
+		got := []interface{}{u, n, f, sub}
+		want := []interface{}{tt.u, tt.n, tt.f, tt.sub}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf(gwxFmt, tt.name, got, want)
+		}
+	}
+
+	t.Run("pooled", func(t *testing.T) {
+		p := AcquireParth(path).WithNumberMode(NumberLast)
+
+		var u uint
+		p.Segment(0, &u)
+		if unx(t, t.Name(), p.Err()) {
+			return
+		}
+
+		if u != 42 {
+			t.Errorf(gwFmt, u, 42)
+		}
+
+		ReleaseParth(p)
+	})
+}
+
+func TestBhvrParthPathLen(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *Parth
+		path string
+		len  int
+	}{
+		{"new", New("/a/b/c"), "/a/b/c", 3},
+		{"trailing slash", New("/a/b/"), "/a/b/", 3},
+		{"trailing slash deeper", New("/a/b/c/"), "/a/b/c/", 4},
+		{"by sub span", NewBySubSpan("/x/k/a/b", "k", 0, 2), "/a/b", 2},
+		{"no leading slash", New("a/b"), "a/b", 2},
+		{"root", New("/"), "/", 1},
+		{"empty", New(""), "", 0},
+		{"by span", NewBySpan("/a/b/c", 1, 0), "/b/c", 2},
+		{"by span error", NewBySpan("/a", 5, 0), "", 0},
+		{"acquired", AcquireParth("/a/b"), "/a/b", 2},
+	}
+
+	for _, tt := range tests {
+		if got := tt.p.Path(); got != tt.path {
+			t.Errorf(gwxFmt, tt.name, got, tt.path)
+		}
+
+		for n := 0; n < 2; n++ {
+			if got := tt.p.Len(); got != tt.len {
+				t.Errorf(gwxFmt, tt.name, got, tt.len)
+			}
+		}
+	}
+
+	t.Run("cached segment", func(t *testing.T) {
+		p := New("/a/42/c")
+		if got := p.Len(); got != 3 {
+			t.Fatalf(gwFmt, got, 3)
+		}
+
+		var got int
+		p.Segment(1, &got)
+		if err := p.Err(); err != nil || got != 42 {
+			t.Errorf(gwFmt, got, 42)
+		}
+	})
+}
+
+func TestBhvrParthWithStrictAbsolute(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+		ck   checkFunc
+	}{
+		{"absolute", "/a/b", "b", unx},
+		{"relative", "a/b", "", exp},
+		{"empty", "", "", exp},
+	}
+
+	for _, tt := range tests {
+		p := New(tt.path).WithStrictAbsolute()
+
+		var got string
+		p.Segment(1, &got)
+		if tt.ck(t, tt.name, p.Err()) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("sentinel", func(t *testing.T) {
+		p := New("a/b").WithStrictAbsolute()
+		if s := p.Span(0, 0); s != "" {
+			t.Errorf(gwFmt, s, "")
+		}
+
+		if err := p.Err(); err != ErrNotAbsolute {
+			t.Errorf(gwFmt, err, ErrNotAbsolute)
+		}
+	})
+
+	t.Run("first error kept", func(t *testing.T) {
+		p := NewBySpan("a/b", 5, 0).WithStrictAbsolute()
+		if err := p.Err(); !errors.Is(err, ErrFirstSegNotFound) {
+			t.Errorf(gwFmt, err, ErrFirstSegNotFound)
+		}
+	})
+
+	t.Run("lenient default", func(t *testing.T) {
+		var got string
+		p := New("a/b")
+		p.Segment(0, &got)
+		if unx(t, t.Name(), p.Err()) {
+			return
+		}
+
+		if got != "a" {
+			t.Errorf(gwFmt, got, "a")
+		}
+	})
+}
+
+func TestBhvrSegmentFloatExponent(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want float64
+	}{
+		{"plus", "/v/3.14e+2", 314},
+		{"bare", "/v/1e3", 1000},
+		{"upper", "/v/1E3x", 1000},
+		{"negative", "/v/2.5e-3", 0.0025},
+		{"dangling", "/v/6e", 6},
+		{"no digits", "/v/2.5e-x", 2.5},
+		{"point after exponent", "/v/1e3.5", 1000},
+	}
+
+	for _, tt := range tests {
+		var got float64
+		err := Segment(tt.path, 1, &got)
+		if unx(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("subseg", func(t *testing.T) {
+		var got float64
+		if err := SubSeg("/k/2.5e-3", "k", 0, &got); err != nil || got != 0.0025 {
+			t.Errorf(gwFmt, got, 0.0025)
+		}
+	})
+}
+
 func segSeqSubSeg(path, key string, i *int, v interface{}) error {
 	if path != "" && key != "" && i != nil {
 		return SubSeg(path, key, *i, v)
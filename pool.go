@@ -0,0 +1,38 @@
+package parth
+
+import (
+	"sync"
+)
+
+var parthPool = sync.Pool{
+	New: func() interface{} {
+		return &Parth{}
+	},
+}
+
+// AcquireParth returns a pointer to an instance of Parth around the provided
+// path from a pool of reusable instances. As with New, the segment indexes of
+// the path are cached up front, though the storage for them is reused. The
+// instance should be returned with ReleaseParth once it is no longer needed.
+func AcquireParth(path string) *Parth {
+	p := parthPool.Get().(*Parth)
+	p.path = path
+	p.idxs = AppendPathIndexes(p.idxs[:0], path)
+
+	return p
+}
+
+// ReleaseParth resets the provided *Parth and returns it to the pool used by
+// AcquireParth. The *Parth must not be used after it has been released.
+func ReleaseParth(p *Parth) {
+	if p == nil {
+		return
+	}
+
+	p.path = ""
+	p.err = nil
+	p.idxs = p.idxs[:0]
+	p.mode = NumberFirst
+
+	parthPool.Put(p)
+}
@@ -0,0 +1,48 @@
+package parth
+
+import "testing"
+
+func TestBhvrAcquireParth(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"first", "/zero/one/two", 0, "zero", unx},
+		{"last", "/zero/one/two", 2, "two", unx},
+		{"empty segment", "/zero//two", 1, "", unx},
+		{"no leading slash", "zero/one", 1, "one", unx},
+		{"out of range", "/zero", 1, "", exp},
+		{"empty path", "", 0, "", exp},
+	}
+
+	for _, tt := range tests {
+		p := AcquireParth(tt.path)
+
+		var got string
+		p.Segment(tt.i, &got)
+		if !tt.ck(t, tt.name, p.Err()) && got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+
+		ReleaseParth(p)
+	}
+}
+
+func TestBhvrReleaseParth(t *testing.T) {
+	p := AcquireParth("/zero/one/two")
+
+	var s string
+	p.Segment(9, &s)
+	exp(t, t.Name(), p.Err())
+
+	ReleaseParth(p)
+
+	if p.path != "" || p.err != nil || len(p.idxs) != 0 {
+		t.Errorf(gwFmt, p, &Parth{})
+	}
+
+	ReleaseParth(nil)
+}
@@ -0,0 +1,208 @@
+package parth
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SegmentHasPrefix reports whether the path segment indicated by the index i
+// begins with prefix. If the index is negative, the negative count begins with
+// the last segment. An error is returned if the index is out of range of the
+// path.
+func SegmentHasPrefix(path string, i int, prefix string) (bool, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return false, err
+	}
+
+	return strings.HasPrefix(s, prefix), nil
+}
+
+// SegmentHasSuffix is similar to SegmentHasPrefix, but reports whether the
+// located segment ends with suffix.
+func SegmentHasSuffix(path string, i int, suffix string) (bool, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return false, err
+	}
+
+	return strings.HasSuffix(s, suffix), nil
+}
+
+// SegmentContains is similar to SegmentHasPrefix, but reports whether sub is
+// within the located segment.
+func SegmentContains(path string, i int, sub string) (bool, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return false, err
+	}
+
+	return strings.Contains(s, sub), nil
+}
+
+// SegmentEquals reports whether the path segment indicated by the index i is
+// exactly equal to want. The comparison is case-sensitive. An error is
+// returned if the index is out of range of the path.
+func SegmentEquals(path string, i int, want string) (bool, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return false, err
+	}
+
+	return s == want, nil
+}
+
+// SegmentsEqual reports whether each path segment indicated by a key of want
+// is exactly equal to the associated value (e.g. {0: "users", 2: "posts"}).
+// Indexes are checked in ascending order, and false is returned upon the first
+// mismatch. An error is returned if an index checked is out of range of the
+// path.
+func SegmentsEqual(path string, want map[int]string) (bool, error) {
+	idxs := make([]int, 0, len(want))
+	for i := range want {
+		idxs = append(idxs, i)
+	}
+	sort.Ints(idxs)
+
+	for _, i := range idxs {
+		ok, err := SegmentEquals(path, i, want[i])
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// FindSegment returns the index of the first path segment that is equal to
+// value. An error is returned if no segment matches.
+func FindSegment(path, value string) (int, error) {
+	return FindSegmentFunc(path, func(seg string) bool {
+		return seg == value
+	})
+}
+
+// FindSegmentFunc is similar to FindSegment, but returns the index of the
+// first path segment for which pred returns true.
+func FindSegmentFunc(path string, pred func(string) bool) (int, error) {
+	i := -1
+	eachSeg(path, func(n int, seg string) bool {
+		if pred(seg) {
+			i = n
+			return false
+		}
+		return true
+	})
+
+	if i < 0 {
+		return 0, ErrKeySegNotFound
+	}
+
+	return i, nil
+}
+
+// CountSegment returns the number of path segments that are equal to value
+// (e.g. "/a/x/b/x" with value "x" results in 2).
+func CountSegment(path, value string) int {
+	return CountSegmentFunc(path, func(seg string) bool {
+		return seg == value
+	})
+}
+
+// CountSegmentFunc is similar to CountSegment, but returns the number of path
+// segments for which pred returns true.
+func CountSegmentFunc(path string, pred func(string) bool) int {
+	var ct int
+	eachSeg(path, func(_ int, seg string) bool {
+		if pred(seg) {
+			ct++
+		}
+		return true
+	})
+
+	return ct
+}
+
+// SegmentAfter returns the path segment that follows the first segment equal to
+// marker (e.g. "/api/users/42" with marker "users" results in "42"). An error
+// wrapping ErrKeySegNotFound is returned if no segment is equal to marker, and
+// an error wrapping ErrFirstSegNotFound is returned if no segment follows it. A
+// trailing slash is ignored, so it does not provide an empty following segment.
+func SegmentAfter(path, marker string) (string, error) {
+	path, _ = cutTrailingSlash(path)
+
+	i, err := FindSegment(path, marker)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q", err, marker)
+	}
+
+	if i+1 >= segCount(path) {
+		return "", fmt.Errorf("%w: nothing after %q", ErrFirstSegNotFound, marker)
+	}
+
+	return segmentToString(path, i+1)
+}
+
+// SegmentBefore is similar to SegmentAfter, but returns the path segment that
+// precedes the first segment equal to marker.
+func SegmentBefore(path, marker string) (string, error) {
+	i, err := FindSegment(path, marker)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q", err, marker)
+	}
+
+	if i == 0 {
+		return "", fmt.Errorf("%w: nothing before %q", ErrFirstSegNotFound, marker)
+	}
+
+	return segmentToString(path, i-1)
+}
+
+// IsNumericSegment reports whether the entirety of the path segment indicated
+// by the index i is a number (i.e. an integer or a float) with no surrounding
+// data. Unlike the numeric handling of Segment, a segment such as "42x" is not
+// considered numeric. An error is returned if the index is out of range of the
+// path.
+func IsNumericSegment(path string, i int) (bool, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return false, err
+	}
+
+	return isNumeric(s), nil
+}
+
+// FirstNonNumericSegment returns the first path segment that is not entirely a
+// number, as with IsNumericSegment, along with its index (e.g. "/1/2/books/3"
+// results in "books" and 2). An error wrapping ErrKeySegNotFound is returned
+// if every segment is numeric. A single trailing slash is not treated as an
+// empty final segment.
+func FirstNonNumericSegment(path string) (string, int, error) {
+	path, _ = cutTrailingSlash(path)
+
+	i, err := FindSegmentFunc(path, func(seg string) bool {
+		return !isNumeric(seg)
+	})
+	if err != nil {
+		return "", -1, fmt.Errorf("%w: every segment is numeric", err)
+	}
+
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", -1, err
+	}
+
+	return s, i, nil
+}
+
+func isNumeric(s string) bool {
+	f, ok := firstFloatFromString(s)
+	if !ok || f != s {
+		return false
+	}
+
+	_, err := strconv.ParseFloat(f, 64)
+	return err == nil
+}
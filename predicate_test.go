@@ -0,0 +1,396 @@
+package parth
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func TestBhvrSegmentHasPrefix(t *testing.T) {
+	path := "/zero/user-one/two"
+
+	tests := []struct {
+		name   string
+		i      int
+		prefix string
+		want   bool
+		ck     checkFunc
+	}{
+		{"match", 1, "user-", true, unx},
+		{"no match", 2, "user-", false, unx},
+		{"empty prefix", 0, "", true, unx},
+		{"longer prefix", 0, "zero-zero", false, unx},
+		{"negative", -2, "user-", true, unx},
+		{"negative last", -1, "user-", false, unx},
+		{"negative out of range", -4, "user-", false, exp},
+		{"bad index", 3, "user-", false, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentHasPrefix(path, tt.i, tt.prefix)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentHasSuffix(t *testing.T) {
+	path := "/zero/one.json/two"
+
+	tests := []struct {
+		name   string
+		i      int
+		suffix string
+		want   bool
+		ck     checkFunc
+	}{
+		{"match", 1, ".json", true, unx},
+		{"no match", 2, ".json", false, unx},
+		{"empty suffix", 0, "", true, unx},
+		{"bad index", 3, ".json", false, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentHasSuffix(path, tt.i, tt.suffix)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentContains(t *testing.T) {
+	path := "/zero/o~ne/two"
+
+	tests := []struct {
+		name string
+		i    int
+		sub  string
+		want bool
+		ck   checkFunc
+	}{
+		{"match", 1, "~", true, unx},
+		{"no match", 2, "~", false, unx},
+		{"empty sub", 0, "", true, unx},
+		{"bad index", 3, "~", false, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentContains(path, tt.i, tt.sub)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentEquals(t *testing.T) {
+	path := "/zero/User/two"
+
+	tests := []struct {
+		name string
+		i    int
+		s    string
+		want bool
+		ck   checkFunc
+	}{
+		{"match", 1, "User", true, unx},
+		{"case differs", 1, "user", false, unx},
+		{"no match", 2, "User", false, unx},
+		{"negative", -2, "User", true, unx},
+		{"negative last", -1, "two", true, unx},
+		{"negative out of range", -4, "User", false, exp},
+		{"bad index", 3, "User", false, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentEquals(path, tt.i, tt.s)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentsEqual(t *testing.T) {
+	path := "/users/7/posts"
+
+	tests := []struct {
+		name string
+		m    map[int]string
+		want bool
+		ck   checkFunc
+	}{
+		{"match", map[int]string{0: "users", 2: "posts"}, true, unx},
+		{"single", map[int]string{1: "7"}, true, unx},
+		{"mismatch", map[int]string{0: "users", 2: "Posts"}, false, unx},
+		{"mismatch before missing", map[int]string{0: "items", 9: "x"}, false, unx},
+		{"missing", map[int]string{0: "users", 9: "x"}, false, exp},
+		{"empty", map[int]string{}, true, unx},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentsEqual(path, tt.m)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrFindSegment(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		value string
+		want  int
+		ck    checkFunc
+	}{
+		{"first", "/api/v1/users", "api", 0, unx},
+		{"middle", "/api/v1/users/v1", "v1", 1, unx},
+		{"no leading slash", "api/v1", "v1", 1, unx},
+		{"empty segment", "/api//users", "", 1, unx},
+		{"partial", "/api/v10/users", "v1", 0, exp},
+		{"empty path", "", "v1", 0, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := FindSegment(tt.path, tt.value)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrFindSegmentFunc(t *testing.T) {
+	numeric := func(s string) bool {
+		_, err := strconv.Atoi(s)
+		return err == nil
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want int
+		ck   checkFunc
+	}{
+		{"found", "/users/me/42/7", 2, unx},
+		{"first", "/42/users", 0, unx},
+		{"missing", "/users/me", 0, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := FindSegmentFunc(tt.path, numeric)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrCountSegment(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		value string
+		want  int
+	}{
+		{"repeated", "/a/x/b/x", "x", 2},
+		{"once", "/a/x/b", "x", 1},
+		{"none", "/a/b", "x", 0},
+		{"exact only", "/x/xx/X", "x", 1},
+		{"empty segments", "/a//b/", "", 2},
+		{"empty path", "", "x", 0},
+	}
+
+	for _, tt := range tests {
+		got := CountSegment(tt.path, tt.value)
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrCountSegmentFunc(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		pred func(string) bool
+		want int
+	}{
+		{"numeric", "/users/7/posts/42", isNumeric, 2},
+		{"none", "/users/posts", isNumeric, 0},
+		{"all", "/a/b/c", func(string) bool { return true }, 3},
+	}
+
+	for _, tt := range tests {
+		got := CountSegmentFunc(tt.path, tt.pred)
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		marker string
+		want   string
+		ck     checkFunc
+	}{
+		{"middle", "/api/users/42", "users", "42", unx},
+		{"first occurrence", "/x/a/x/b", "x", "a", unx},
+		{"mount prefix", "/v2/mnt/api/users/7/posts", "users", "7", unx},
+		{"last", "/api/users", "users", "", exp},
+		{"last trailing slash", "/api/users/", "users", "", exp},
+		{"middle trailing slash", "/api/users/42/", "users", "42", unx},
+		{"missing", "/api/users/42", "posts", "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentAfter(tt.path, tt.marker)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("sentinels", func(t *testing.T) {
+		_, err := SegmentAfter("/api/users", "posts")
+		if !errors.Is(err, ErrKeySegNotFound) {
+			t.Errorf(gwFmt, err, ErrKeySegNotFound)
+		}
+
+		_, err = SegmentAfter("/api/users", "users")
+		if !errors.Is(err, ErrFirstSegNotFound) {
+			t.Errorf(gwFmt, err, ErrFirstSegNotFound)
+		}
+	})
+}
+
+func TestBhvrSegmentBefore(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		marker string
+		want   string
+		ck     checkFunc
+	}{
+		{"middle", "/api/users/42", "users", "api", unx},
+		{"first occurrence", "/a/x/b/x", "x", "a", unx},
+		{"first", "/api/users", "api", "", exp},
+		{"missing", "/api/users", "posts", "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentBefore(tt.path, tt.marker)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrIsNumericSegment(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want bool
+		ck   checkFunc
+	}{
+		{"int", "/users/42/", 1, true, unx},
+		{"neg int", "/users/-42", 1, true, unx},
+		{"float", "/users/4.2", 1, true, unx},
+		{"exponent", "/users/4e2", 1, true, unx},
+		{"text", "/users/me/", 1, false, unx},
+		{"noise", "/users/42x", 1, false, unx},
+		{"lone sign", "/users/-", 1, false, unx},
+		{"inf", "/users/Inf", 1, false, unx},
+		{"empty", "/users//", 1, false, unx},
+		{"bad index", "/users/42", 2, false, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := IsNumericSegment(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrFirstNonNumericSegment(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		want  string
+		wantI int
+		ck    checkFunc
+	}{
+		{"middle", "/1/2/books/3", "books", 2, unx},
+		{"first", "/books/1", "books", 0, unx},
+		{"noise", "/1/2x/3", "2x", 1, unx},
+		{"float prefix", "/1.5/-2/v1", "v1", 2, unx},
+		{"empty seg", "/1//3", "", 1, unx},
+		{"all numeric", "/1/2.5/-3/", "", -1, exp},
+		{"empty", "", "", -1, exp},
+	}
+
+	for _, tt := range tests {
+		got, gotI, err := FirstNonNumericSegment(tt.path)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want || gotI != tt.wantI {
+			t.Errorf(gwxFmt, tt.name, fmt.Sprint(got, gotI), fmt.Sprint(tt.want, tt.wantI))
+		}
+	}
+
+	t.Run("message", func(t *testing.T) {
+		_, _, err := FirstNonNumericSegment("/1/2")
+		if !errors.Is(err, ErrKeySegNotFound) {
+			t.Fatalf(gwxFmt, "is", err, ErrKeySegNotFound)
+		}
+
+		want := ErrKeySegNotFound.Error() + ": every segment is numeric"
+		if got := err.Error(); got != want {
+			t.Errorf(gwxFmt, "message", got, want)
+		}
+	})
+}
@@ -0,0 +1,164 @@
+package parth
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Scan unmarshals consecutive path segments, beginning with the first, into
+// the provided values. Each value is handled in the same manner as with
+// Segment (e.g. parth.Scan("/7/bob", &id, &name)). An error is returned if any
+// value cannot be unmarshaled (see Segment), in which case the error reports
+// the failed position and wraps the underlying error.
+func Scan(path string, dest ...interface{}) error {
+	return ScanFrom(path, 0, dest...)
+}
+
+// ScanFrom is similar to Scan, but begins with the path segment indicated by
+// the index start (e.g. a start of 2 skips "/api/v1"). An error is returned if
+// the index is negative.
+func ScanFrom(path string, start int, dest ...interface{}) error {
+	if start < 0 {
+		return fmt.Errorf("scan start %d: %w", start, ErrFirstSegNotFound)
+	}
+
+	for n, v := range dest {
+		if err := Segment(path, start+n, v); err != nil {
+			return fmt.Errorf("scan position %d: %w", start+n, err)
+		}
+	}
+
+	return nil
+}
+
+// PathToMap pairs consecutive path segments, beginning with the segment
+// indicated by the index start, as keys and values (e.g. "/filter/color/red"
+// with a start of 1 results in {color: red}). Later duplicate keys overwrite
+// earlier ones. A single trailing slash is ignored. An error is returned if:
+// 1. The index is negative or out of range of the path; 2. An odd number of
+// segments remain, in which case the error wraps ErrDataUnparsable.
+func PathToMap(path string, start int) (map[string]string, error) {
+	path, _ = cutTrailingSlash(path)
+
+	ct := editSegCount(path)
+	if start < 0 || start > ct {
+		return nil, fmt.Errorf("map start %d: %w", start, ErrFirstSegNotFound)
+	}
+	if (ct-start)%2 != 0 {
+		return nil, fmt.Errorf("%w: odd number of segments (%d) from %d", ErrDataUnparsable, ct-start, start)
+	}
+
+	m := make(map[string]string, (ct-start)/2)
+
+	var key string
+	eachSeg(path, func(n int, seg string) bool {
+		switch {
+		case n < start:
+		case (n-start)%2 == 0:
+			key = seg
+		default:
+			m[key] = seg
+		}
+		return true
+	})
+
+	return m, nil
+}
+
+// SegmentStrings returns every path segment keyed by its index (e.g. "/a/b/c"
+// results in {0: a, 1: b, 2: c}). Only non-negative indexes are included, and
+// each matches the index that would be used with Segment, so a trailing slash
+// results in a final empty segment. An error is returned if the path is empty.
+func SegmentStrings(path string) (map[int]string, error) {
+	if path == "" {
+		return nil, ErrFirstSegNotFound
+	}
+
+	m := make(map[int]string, segCount(path))
+	eachSeg(path, func(n int, seg string) bool {
+		m[n] = seg
+		return true
+	})
+
+	return m, nil
+}
+
+// SegmentScanner steps through the segments of a path one at a time, in the
+// manner of bufio.Scanner, without allocating. Segments are delimited the same
+// way as they are for Segment, so an index reported by a SegmentScanner can be
+// used with the other functions of this package.
+type SegmentScanner struct {
+	path string
+	next int
+	text string
+	idx  int
+}
+
+// NewSegmentScanner returns a SegmentScanner that reads the segments of path.
+func NewSegmentScanner(path string) *SegmentScanner {
+	s := &SegmentScanner{path: path, idx: -1, next: -1}
+	if path == "" {
+		return s
+	}
+
+	s.next = 0
+	if path[0] == '/' {
+		s.next = 1
+	}
+
+	return s
+}
+
+// Scan advances the SegmentScanner to the next segment, which will then be
+// available through the Text and Index methods. It returns false when no
+// segments remain.
+func (s *SegmentScanner) Scan() bool {
+	text, next, ok := s.peek()
+	if !ok {
+		s.text = ""
+		return false
+	}
+
+	s.text, s.next = text, next
+	s.idx++
+
+	return true
+}
+
+// Peek returns the segment that the next call to Scan will advance to without
+// advancing the SegmentScanner. It returns false when no segments remain.
+func (s *SegmentScanner) Peek() (string, bool) {
+	text, _, ok := s.peek()
+	return text, ok
+}
+
+func (s *SegmentScanner) peek() (string, int, bool) {
+	if s.next < 0 {
+		return "", -1, false
+	}
+
+	e := strings.IndexByte(s.path[s.next:], '/')
+	if e < 0 {
+		return s.path[s.next:], -1, true
+	}
+
+	return s.path[s.next : s.next+e], s.next + e + 1, true
+}
+
+// Text returns the segment most recently located by a call to Scan.
+func (s *SegmentScanner) Text() string {
+	return s.text
+}
+
+// Index returns the index of the segment most recently located by a call to
+// Scan, or -1 if Scan has not yet located a segment.
+func (s *SegmentScanner) Index() int {
+	return s.idx
+}
+
+// Err returns the first error encountered by the SegmentScanner. It is
+// provided for parity with bufio.Scanner; scanning a path cannot currently
+// fail, so the returned error is always nil.
+func (s *SegmentScanner) Err() error {
+	return nil
+}
@@ -0,0 +1,254 @@
+package parth
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBhvrScan(t *testing.T) {
+	t.Run("basic", func(t *testing.T) {
+		var id int
+		var name string
+		var active bool
+
+		err := Scan("/7/bob/true", &id, &name, &active)
+		if unx(t, t.Name(), err) {
+			return
+		}
+
+		if id != 7 || name != "bob" || !active {
+			t.Errorf(gwFmt, []interface{}{id, name, active}, []interface{}{7, "bob", true})
+		}
+	})
+
+	t.Run("fewer dest", func(t *testing.T) {
+		var id int
+
+		err := Scan("/7/bob", &id)
+		if unx(t, t.Name(), err) {
+			return
+		}
+
+		if id != 7 {
+			t.Errorf(gwFmt, id, 7)
+		}
+	})
+
+	t.Run("too few segments", func(t *testing.T) {
+		var id int
+		var name, extra string
+
+		err := Scan("/7/bob", &id, &name, &extra)
+		if exp(t, t.Name(), err) {
+			return
+		}
+
+		want := "scan position 2: first segment not found by index"
+		if err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		var id int
+		var x uintptr
+
+		err := Scan("/7/bob", &id, &x)
+		if !errors.Is(err, ErrUnknownType) {
+			t.Errorf(gwFmt, err, ErrUnknownType)
+		}
+	})
+
+	t.Run("unparsable", func(t *testing.T) {
+		var id int
+
+		err := Scan("/bob", &id)
+		if !errors.Is(err, ErrDataUnparsable) {
+			t.Errorf(gwFmt, err, ErrDataUnparsable)
+		}
+	})
+}
+
+func TestBhvrScanFrom(t *testing.T) {
+	t.Run("basic", func(t *testing.T) {
+		var id int
+		var name string
+
+		err := ScanFrom("/api/v1/7/bob", 2, &id, &name)
+		if unx(t, t.Name(), err) {
+			return
+		}
+
+		if id != 7 || name != "bob" {
+			t.Errorf(gwFmt, []interface{}{id, name}, []interface{}{7, "bob"})
+		}
+	})
+
+	t.Run("beyond path", func(t *testing.T) {
+		var id int
+		var name string
+
+		err := ScanFrom("/api/v1/7", 2, &id, &name)
+		if exp(t, t.Name(), err) {
+			return
+		}
+
+		want := "scan position 3: first segment not found by index"
+		if err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+	})
+
+	t.Run("negative start", func(t *testing.T) {
+		var id int
+
+		err := ScanFrom("/api/v1/7", -1, &id)
+		if !errors.Is(err, ErrFirstSegNotFound) {
+			t.Errorf(gwFmt, err, ErrFirstSegNotFound)
+		}
+	})
+}
+
+func TestBhvrSegmentScanner(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{"basic", "/a/b/c", []string{"a", "b", "c"}},
+		{"no leading slash", "a/b", []string{"a", "b"}},
+		{"trailing slash", "/a/b/", []string{"a", "b", ""}},
+		{"empty middle", "/a//b", []string{"a", "", "b"}},
+		{"root", "/", []string{""}},
+		{"empty", "", nil},
+	}
+
+	for _, tt := range tests {
+		sc := NewSegmentScanner(tt.path)
+		if sc.Index() != -1 {
+			t.Errorf(gwxFmt, tt.name, sc.Index(), -1)
+		}
+
+		var got []string
+		for sc.Scan() {
+			if sc.Index() != len(got) {
+				t.Errorf(gwxFmt, tt.name, sc.Index(), len(got))
+			}
+
+			if s, err := segmentToString(tt.path, sc.Index()); err != nil || s != sc.Text() {
+				t.Errorf(gwxFmt, tt.name, s, sc.Text())
+			}
+
+			got = append(got, sc.Text())
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+
+		if sc.Scan() || sc.Text() != "" {
+			t.Errorf(gwxFmt, tt.name, sc.Text(), "")
+		}
+
+		if err := sc.Err(); err != nil {
+			t.Errorf(gwxFmt, tt.name, err, nil)
+		}
+	}
+
+	t.Run("peek", func(t *testing.T) {
+		sc := NewSegmentScanner("/a/b")
+
+		for _, want := range []string{"a", "b"} {
+			got, ok := sc.Peek()
+			if !ok || got != want {
+				t.Errorf(gwFmt, got, want)
+			}
+
+			if !sc.Scan() || sc.Text() != want {
+				t.Errorf(gwFmt, sc.Text(), want)
+			}
+		}
+
+		if got, ok := sc.Peek(); ok {
+			t.Errorf(gwFmt, got, "")
+		}
+	})
+}
+
+func TestBhvrPathToMap(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		start int
+		want  map[string]string
+		ck    checkFunc
+	}{
+		{"pairs", "/filter/color/red/size/large", 1, map[string]string{"color": "red", "size": "large"}, unx},
+		{"from start", "/color/red", 0, map[string]string{"color": "red"}, unx},
+		{"trailing slash", "/filter/color/red/", 1, map[string]string{"color": "red"}, unx},
+		{"duplicate key", "/f/a/1/a/2", 1, map[string]string{"a": "2"}, unx},
+		{"empty value", "/f/a//b/2", 1, map[string]string{"a": "", "b": "2"}, unx},
+		{"none remaining", "/filter", 1, map[string]string{}, unx},
+		{"odd", "/filter/color/red/size", 1, nil, exp},
+		{"out of range", "/filter", 2, nil, exp},
+		{"negative", "/filter/a/b", -1, nil, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := PathToMap(tt.path, tt.start)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("message", func(t *testing.T) {
+		_, err := PathToMap("/filter/color/red/size", 1)
+		if !errors.Is(err, ErrDataUnparsable) {
+			t.Fatalf(gwFmt, err, ErrDataUnparsable)
+		}
+
+		want := "data cannot be parsed: odd number of segments (3) from 1"
+		if err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+	})
+}
+
+func TestBhvrSegmentStrings(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want map[int]string
+		ck   checkFunc
+	}{
+		{"basic", "/a/b/c", map[int]string{0: "a", 1: "b", 2: "c"}, unx},
+		{"no leading slash", "a/b", map[int]string{0: "a", 1: "b"}, unx},
+		{"trailing slash", "/a/b/", map[int]string{0: "a", 1: "b", 2: ""}, unx},
+		{"empty middle", "/a//b", map[int]string{0: "a", 1: "", 2: "b"}, unx},
+		{"root", "/", map[int]string{0: ""}, unx},
+		{"empty", "", nil, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentStrings(tt.path)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+			continue
+		}
+
+		for i, want := range got {
+			if s, err := segmentToString(tt.path, i); err != nil || s != want {
+				t.Errorf(gwxFmt, tt.name, s, want)
+			}
+		}
+	}
+}
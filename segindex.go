@@ -1,5 +1,42 @@
 package parth
 
+import (
+	"strings"
+)
+
+// AppendPathIndexes appends the segment offsets of the path to dst and returns
+// the extended slice. Each segment is represented by the offset at which it
+// begins (i.e. the index of its leading slash, or 0 for a first segment that
+// does not begin with a slash), and a final offset equal to the length of the
+// path is appended as a virtual end. Consequently, segment n spans
+// path[idx[n]:idx[n+1]]. Nothing is appended for an empty path. Providing a
+// dst with sufficient capacity (e.g. one reused via a sync.Pool) avoids
+// allocation.
+func AppendPathIndexes(dst []int, path string) []int {
+	if path == "" {
+		return dst
+	}
+
+	dst = append(dst, 0)
+	for n := 1; n < len(path); n++ {
+		if path[n] == '/' {
+			dst = append(dst, n)
+		}
+	}
+
+	return append(dst, len(path))
+}
+
+// PathIndexes returns the segment offsets of the path as described by
+// AppendPathIndexes (e.g. "/a/bc/" results in [0 2 5 6]: the leading slash of
+// each of the segments "a", "bc", and "", followed by the virtual end). This
+// is the same offset table used internally to locate segments, so a segment
+// path[idx[n]:idx[n+1]] begins with a slash unless it is a first segment that
+// does not. A nil slice is returned for an empty path.
+func PathIndexes(path string) []int {
+	return AppendPathIndexes(nil, path)
+}
+
 func segStartIndexFromStart(path string, seg int) (int, bool) {
 	if seg < 0 {
 		return 0, false
@@ -116,3 +153,72 @@ func segIndexByKey(path, key string) (int, bool) { //nolint
 
 	return 0, false
 }
+
+func segBounds(path string, seg int) (int, int, bool) {
+	var f, l int
+	var ok bool
+
+	if seg < 0 {
+		f, ok = segStartIndexFromEnd(path, seg)
+	} else {
+		f, ok = segStartIndexFromStart(path, seg)
+	}
+	if !ok {
+		return 0, 0, false
+	}
+
+	if seg < 0 {
+		l, ok = segEndIndexFromEnd(path, seg+1)
+	} else {
+		l, ok = segEndIndexFromStart(path, seg+1)
+	}
+	if !ok {
+		return 0, 0, false
+	}
+
+	if path[f] == '/' {
+		f++
+	}
+
+	return f, l, true
+}
+
+func segCount(path string) int {
+	if path == "" {
+		return 0
+	}
+
+	ct := 1
+	for n := 1; n < len(path); n++ {
+		if path[n] == '/' {
+			ct++
+		}
+	}
+
+	return ct
+}
+
+func eachSeg(path string, fn func(n int, seg string) bool) {
+	if path == "" {
+		return
+	}
+
+	f := 0
+	if path[0] == '/' {
+		f = 1
+	}
+
+	for n := 0; ; n++ {
+		e := strings.IndexByte(path[f:], '/')
+		if e < 0 {
+			fn(n, path[f:])
+			return
+		}
+
+		if !fn(n, path[f:f+e]) {
+			return
+		}
+
+		f += e + 1
+	}
+}
@@ -1,6 +1,10 @@
 package parth
 
-import "testing"
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
 
 func TestUnitSegStartIndexFromEnd(t *testing.T) {
 	tests := []struct {
@@ -167,3 +171,142 @@ func TestUnitSegIndexByKey(t *testing.T) {
 		}
 	}
 }
+
+func TestUnitSegBounds(t *testing.T) {
+	tests := []struct {
+		i      int
+		s      string
+		f, l   int
+		okWant bool
+	}{
+		{0, "/test1", 1, 6, true},
+		{1, "/test1/t-2/t_3", 7, 10, true},
+		{-1, "/test1/t-2/t_3", 11, 14, true},
+		{-3, "/test1/t-2/t_3", 1, 6, true},
+		{0, "test4/t4", 0, 5, true},
+		{-2, "test4/t4", 0, 5, true},
+		{1, "/0//2", 3, 3, true},
+		{0, "/", 1, 1, true},
+		{-1, "/", 1, 1, true},
+		{3, "/test/out", 0, 0, false},
+		{-3, "/test/out", 0, 0, false},
+		{0, "", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		f, l, okGot := segBounds(tt.s, tt.i)
+		if okGot != tt.okWant {
+			t.Errorf(gwxFmt, tt.s, okGot, tt.okWant)
+			continue
+		}
+
+		if f != tt.f || l != tt.l {
+			t.Errorf(gwxFmt, tt.s, [2]int{f, l}, [2]int{tt.f, tt.l})
+		}
+	}
+}
+
+func TestUnitSegCount(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"/test1", 1},
+		{"/test1/t-2/t_3", 3},
+		{"test4/t4", 2},
+		{"/0//2", 3},
+		{"/0/1/", 3},
+		{"/", 1},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		got := segCount(tt.s)
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrAppendPathIndexes(t *testing.T) {
+	tests := []struct {
+		s    string
+		dst  []int
+		want []int
+	}{
+		{"/test1", nil, []int{0, 6}},
+		{"/test1/t-2/t_3", nil, []int{0, 6, 10, 14}},
+		{"test4/t4", nil, []int{0, 5, 8}},
+		{"/0//2", nil, []int{0, 2, 3, 5}},
+		{"/0/1/", nil, []int{0, 2, 4, 5}},
+		{"/", nil, []int{0, 1}},
+		{"", nil, nil},
+		{"/a", []int{9}, []int{9, 0, 2}},
+	}
+
+	for _, tt := range tests {
+		got := AppendPathIndexes(tt.dst, tt.s)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf(gwxFmt, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrPathIndexes(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []int
+	}{
+		{"/a/bc/", []int{0, 2, 5, 6}},
+		{"a/b", []int{0, 1, 3}},
+		{"/", []int{0, 1}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		got := PathIndexes(tt.s)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf(gwxFmt, tt.s, got, tt.want)
+			continue
+		}
+
+		for n := 0; n+1 < len(got); n++ {
+			want, _ := segmentToString(tt.s, n)
+			if seg := strings.TrimPrefix(tt.s[got[n]:got[n+1]], "/"); seg != want {
+				t.Errorf(gwxFmt, tt.s, seg, want)
+			}
+		}
+	}
+}
+
+func TestUnitEachSeg(t *testing.T) {
+	tests := []struct {
+		s    string
+		stop int
+		want []string
+	}{
+		{"/test1", -1, []string{"test1"}},
+		{"/test1/t-2/t_3", -1, []string{"test1", "t-2", "t_3"}},
+		{"/test1/t-2/t_3", 1, []string{"test1", "t-2"}},
+		{"test4/t4", -1, []string{"test4", "t4"}},
+		{"/0//2", -1, []string{"0", "", "2"}},
+		{"/0/1/", -1, []string{"0", "1", ""}},
+		{"/", -1, []string{""}},
+		{"", -1, nil},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		eachSeg(tt.s, func(n int, seg string) bool {
+			if n != len(got) {
+				t.Errorf(gwxFmt, tt.s, n, len(got))
+			}
+			got = append(got, seg)
+			return n != tt.stop
+		})
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf(gwxFmt, tt.s, got, tt.want)
+		}
+	}
+}
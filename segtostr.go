@@ -2,7 +2,7 @@ package parth
 
 import (
 	"strconv"
-	"unicode"
+	"strings"
 )
 
 func segmentToBool(path string, i int) (bool, error) {
@@ -19,76 +19,101 @@ func segmentToBool(path string, i int) (bool, error) {
 	return v, nil
 }
 
-func segmentToFloatN(path string, i, size int) (float64, error) {
+func segmentToFloatN(path string, i, size int, m NumberMode) (float64, error) {
+	if m == NumberFirst {
+		return segmentToFloatNSep(path, i, size, '.')
+	}
+
 	ss, err := segmentToString(path, i)
 	if err != nil {
 		return 0.0, err
 	}
 
-	s, ok := firstFloatFromString(ss)
+	s, ok := floatFromString(ss, m)
 	if !ok {
-		return 0.0, err
+		return 0.0, ErrDataUnparsable
 	}
 
 	v, err := strconv.ParseFloat(s, size)
 	if err != nil {
+		return 0.0, newParseError(i, s, err)
+	}
+
+	return v, nil
+}
+
+func segmentToFloatNSep(path string, i, size int, sep byte) (float64, error) {
+	ss, err := segmentToString(path, i)
+	if err != nil {
+		return 0.0, err
+	}
+
+	s, ok := firstFloatFromStringSep(ss, sep)
+	if !ok {
 		return 0.0, ErrDataUnparsable
 	}
 
+	if sep != '.' {
+		s = strings.Replace(s, string(sep), ".", 1)
+	}
+
+	v, err := strconv.ParseFloat(s, size)
+	if err != nil {
+		return 0.0, newParseError(i, s, err)
+	}
+
 	return v, nil
 }
 
-func segmentToIntN(path string, i, size int) (int64, error) {
+func segmentToIntN(path string, i, size int, m NumberMode) (int64, error) {
 	ss, err := segmentToString(path, i)
 	if err != nil {
 		return 0, err
 	}
 
-	s, ok := firstIntFromString(ss)
+	s, ok := intFromString(ss, m)
 	if !ok {
 		return 0, ErrDataUnparsable
 	}
 
 	v, err := strconv.ParseInt(s, 10, size)
 	if err != nil {
-		return 0, ErrDataUnparsable
+		return 0, newParseError(i, s, err)
 	}
 
 	return v, nil
 }
 
+// segmentToString returns the path segment indicated by the index i. If the
+// index is negative, a single trailing slash is ignored so that the negative
+// count begins with the last non-empty segment.
 func segmentToString(path string, i int) (string, error) {
-	j := i + 1
 	if i < 0 {
-		i--
-	}
-
-	s, err := Span(path, i, j)
-	if err != nil {
-		return "", err
+		path, _ = cutTrailingSlash(path)
 	}
 
-	if s[0] == '/' {
-		s = s[1:]
+	f, l, ok := segBounds(path, i)
+	if !ok {
+		return "", ErrFirstSegNotFound
 	}
 
-	return s, nil
+	return path[f:l], nil
 }
 
-func segmentToUintN(path string, i, size int) (uint64, error) {
+func segmentToUintN(path string, i, size int, m NumberMode) (uint64, error) {
 	ss, err := segmentToString(path, i)
 	if err != nil {
 		return 0, err
 	}
 
-	s, ok := firstUintFromString(ss)
+	s, ok := uintFromString(ss, m)
 	if !ok {
 		return 0, ErrDataUnparsable
 	}
 
 	v, err := strconv.ParseUint(s, 10, size)
 	if err != nil {
-		return 0, ErrDataUnparsable
+		return 0, newParseError(i, s, err)
 	}
 
 	return v, nil
@@ -108,39 +133,39 @@ func subSegToBool(path, key string, i int) (bool, error) {
 	return v, nil
 }
 
-func subSegToFloatN(path, key string, i, size int) (float64, error) {
+func subSegToFloatN(path, key string, i, size int, m NumberMode) (float64, error) {
 	ss, err := subSegToString(path, key, i)
 	if err != nil {
 		return 0.0, err
 	}
 
-	s, ok := firstFloatFromString(ss)
+	s, ok := floatFromString(ss, m)
 	if !ok {
 		return 0.0, ErrDataUnparsable
 	}
 
 	v, err := strconv.ParseFloat(s, size)
 	if err != nil {
-		return 0.0, ErrDataUnparsable
+		return 0.0, newParseError(i, s, err)
 	}
 
 	return v, nil
 }
 
-func subSegToIntN(path, key string, i, size int) (int64, error) {
+func subSegToIntN(path, key string, i, size int, m NumberMode) (int64, error) {
 	ss, err := subSegToString(path, key, i)
 	if err != nil {
 		return 0, err
 	}
 
-	s, ok := firstIntFromString(ss)
+	s, ok := intFromString(ss, m)
 	if !ok {
 		return 0, ErrDataUnparsable
 	}
 
 	v, err := strconv.ParseInt(s, 10, size)
 	if err != nil {
-		return 0, ErrDataUnparsable
+		return 0, newParseError(i, s, err)
 	}
 
 	return v, nil
@@ -162,106 +187,235 @@ func subSegToString(path, key string, i int) (string, error) {
 	return s, nil
 }
 
-func subSegToUintN(path, key string, i, size int) (uint64, error) {
+func subSegToUintN(path, key string, i, size int, m NumberMode) (uint64, error) {
 	ss, err := subSegToString(path, key, i)
 	if err != nil {
 		return 0, err
 	}
 
-	s, ok := firstUintFromString(ss)
+	s, ok := uintFromString(ss, m)
 	if !ok {
 		return 0, ErrDataUnparsable
 	}
 
 	v, err := strconv.ParseUint(s, 10, size)
 	if err != nil {
-		return 0, ErrDataUnparsable
+		return 0, newParseError(i, s, err)
 	}
 
 	return v, nil
 }
 
-func firstUintFromString(s string) (string, bool) {
-	ind, l := 0, 0
+func intFromString(s string, m NumberMode) (string, bool) {
+	if m == NumberLast {
+		return lastIntToken(s, true)
+	}
 
-	for n := 0; n < len(s); n++ {
-		if unicode.IsDigit(rune(s[n])) {
-			if l == 0 {
-				ind = n
-			}
+	return firstIntFromString(s)
+}
 
-			l++
-		} else {
-			if l == 0 && s[n] == '.' {
-				if n+1 < len(s) && unicode.IsDigit(rune(s[n+1])) {
-					return "0", true
-				}
+func uintFromString(s string, m NumberMode) (string, bool) {
+	if m == NumberLast {
+		return lastIntToken(s, false)
+	}
 
-				break
-			}
+	return firstUintFromString(s)
+}
 
-			if l > 0 {
-				break
+func floatFromString(s string, m NumberMode) (string, bool) {
+	if m != NumberLast {
+		return firstFloatFromString(s)
+	}
+
+	var last string
+	for off := 0; off < len(s); {
+		ind, l, ok := firstFloatIndexSep(s[off:], '.')
+		if l == 0 {
+			break
+		}
+
+		if tok := s[off+ind : off+ind+l]; ok && tok != "-" {
+			if last != "" {
+				tok = unsignAfterWord(s, off+ind, tok)
 			}
+			last = tok
 		}
+		off += ind + l
 	}
 
-	if l == 0 {
-		return "", false
+	return last, last != ""
+}
+
+func firstUintFromString(s string) (string, bool) {
+	tok, _, ok := nextIntToken(s, 0, false)
+	return tok, ok
+}
+
+func firstIntFromString(s string) (string, bool) {
+	tok, _, ok := nextIntToken(s, 0, true)
+	return tok, ok
+}
+
+// lastIntToken returns the last integer-like token in s. Once a token has been
+// found, a '-' that directly follows an ASCII letter or digit is treated as a
+// separator rather than as a sign (e.g. "v1-build-42" results in "42", while
+// "x-5" results in "-5").
+func lastIntToken(s string, signed bool) (string, bool) {
+	var last string
+	for n := 0; n < len(s); {
+		tok, next, ok := nextIntToken(s, n, signed)
+		if !ok {
+			break
+		}
+
+		if last != "" {
+			tok = unsignAfterWord(s, next-len(tok), tok)
+		}
+		last = tok
+		n = next
 	}
 
-	return s[ind : ind+l], true
+	return last, last != ""
+}
+
+// unsignAfterWord returns tok, which begins at offset f of s, without its
+// leading '-' if that '-' directly follows an ASCII letter or digit.
+func unsignAfterWord(s string, f int, tok string) string {
+	if tok[0] != '-' || f == 0 {
+		return tok
+	}
+
+	if c := s[f-1]; isDigit(c) || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' {
+		return tok[1:]
+	}
+
+	return tok
 }
 
-func firstIntFromString(s string) (string, bool) { //nolint
-	ind, l := 0, 0
+// nextIntToken returns the first decimal integer token in s at or after offset
+// n along with the offset at which scanning for a subsequent token can resume.
+// Digits that directly follow a decimal point are fractional: if the point
+// follows a digit, they belong to the preceding number and are skipped (e.g.
+// "1.25" holds only "1"), and otherwise they result in a token of "0".
+func nextIntToken(s string, n int, signed bool) (string, int, bool) {
+	for {
+		f, l, ok := intTokenIndex(s, n, signed, isDigit)
+		if !ok {
+			return "", l, false
+		}
 
-	for n := 0; n < len(s); n++ {
-		if unicode.IsDigit(rune(s[n])) {
-			if l == 0 {
-				ind = n
-			}
+		d := f
+		if s[d] == '-' {
+			d++
+		}
 
-			l++
-		} else if s[n] == '-' {
-			if l == 0 {
-				ind = n
-				l++
-			} else {
-				break
+		if d > 0 && s[d-1] == '.' {
+			if d > 1 && isDigit(s[d-2]) {
+				n = l
+				continue
 			}
-		} else {
-			if l == 0 && s[n] == '.' {
-				if n+1 < len(s) && unicode.IsDigit(rune(s[n+1])) {
-					return "0", true
-				}
 
-				break
-			}
+			return "0", l, true
+		}
 
-			if l > 0 {
-				break
-			}
+		return s[f:l], l, true
+	}
+}
+
+// intTokenIndex returns the start and end offsets of the first run of bytes in
+// s at or after offset n for which isBaseDigit reports true. If signed, the run
+// includes a directly preceding '-', so a '-' that does not precede a digit is
+// skipped as data (e.g. "a-b5" results in "5"). Both offsets are len(s) and ok
+// is false if no such run is found.
+func intTokenIndex(s string, n int, signed bool, isBaseDigit func(byte) bool) (int, int, bool) {
+	for n < len(s) && !isBaseDigit(s[n]) {
+		n++
+	}
+	if n == len(s) {
+		return n, n, false
+	}
+
+	f := n
+	for n < len(s) && isBaseDigit(s[n]) {
+		n++
+	}
+
+	if signed && f > 0 && s[f-1] == '-' {
+		f--
+	}
+
+	return f, n, true
+}
+
+// hasFloatStart reports whether s begins with a digit or with sep followed by
+// a digit.
+func hasFloatStart(s string, sep byte) bool {
+	if len(s) > 1 && s[0] == sep {
+		s = s[1:]
+	}
+
+	return s != "" && isDigit(s[0])
+}
+
+// isDigit reports whether c is an ASCII digit. Only ASCII digits are treated
+// as numeric data.
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// isDigits reports whether s is not empty and consists only of ASCII digits.
+func isDigits(s string) bool {
+	for n := 0; n < len(s); n++ {
+		if !isDigit(s[n]) {
+			return false
 		}
 	}
 
-	if l == 0 {
+	return s != ""
+}
+
+// isDecimalFloat reports whether s is not empty and consists only of ASCII
+// digits, signs, periods, and exponent markers, which excludes the special
+// values and hexadecimal forms otherwise accepted by strconv.ParseFloat.
+func isDecimalFloat(s string) bool {
+	for n := 0; n < len(s); n++ {
+		switch c := s[n]; {
+		case isDigit(c), c == '+', c == '-', c == '.', c == 'e', c == 'E':
+		default:
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+func firstFloatFromString(s string) (string, bool) {
+	return firstFloatFromStringSep(s, '.')
+}
+
+func firstFloatFromStringSep(s string, sep byte) (string, bool) {
+	ind, l, ok := firstFloatIndexSep(s, sep)
+	if !ok {
 		return "", false
 	}
 
 	return s[ind : ind+l], true
 }
 
-func firstFloatFromString(s string) (string, bool) { //nolint
-	c, ind, l := 0, 0, 0
+// firstFloatIndexSep returns the offset and length of the first float-like
+// token in s. The length is 0 only when no token is found, and ok is false if
+// the found token consists only of the separator.
+func firstFloatIndexSep(s string, sep byte) (int, int, bool) { //nolint
+	c, d, e, ind, l := 0, 0, 0, 0, 0
 
 	for n := 0; n < len(s); n++ {
-		if unicode.IsDigit(rune(s[n])) {
+		if isDigit(s[n]) {
 			if l == 0 {
 				ind = n
 			}
 
 			l++
+			d++
 		} else if s[n] == '-' {
 			if l == 0 {
 				ind = n
@@ -269,27 +423,33 @@ func firstFloatFromString(s string) (string, bool) { //nolint
 			} else {
 				break
 			}
-		} else if s[n] == '.' {
+		} else if s[n] == '+' && l == 0 && hasFloatStart(s[n+1:], sep) {
+			ind = n
+			l++
+		} else if s[n] == sep {
 			if l == 0 {
 				ind = n
 			}
 
-			if c > 0 {
+			if c > 0 || e > 0 {
 				break
 			}
 
 			l++
 			c++
-		} else if s[n] == 'e' && l > 0 && n+1 < len(s) && s[n+1] == '+' {
-			l++
-		} else if s[n] == '+' && l > 0 && s[n-1] == 'e' {
-			if n+1 < len(s) && unicode.IsDigit(rune(s[n+1])) {
-				l++
-				continue
+		} else if (s[n] == 'e' || s[n] == 'E') && d > 0 && e == 0 {
+			m := n + 1
+			if m < len(s) && (s[m] == '+' || s[m] == '-') {
+				m++
 			}
 
-			l--
-			break
+			if m == len(s) || !isDigit(s[m]) {
+				break
+			}
+
+			l += m - n
+			n = m - 1
+			e++
 		} else {
 			if l > 0 {
 				break
@@ -297,9 +457,9 @@ func firstFloatFromString(s string) (string, bool) { //nolint
 		}
 	}
 
-	if l == 0 || s[ind:ind+l] == "." {
-		return "", false
+	if l == 0 || l == 1 && s[ind] == sep {
+		return ind, l, false
 	}
 
-	return s[ind : ind+l], true
+	return ind, l, true
 }
@@ -22,8 +22,31 @@ func TestUnitFirstFloatFromString(t *testing.T) {
 		{"/3.14e.+12", "3.14", true},
 		{"/3.14e+.13", "3.14", true},
 		{"/3.14e+.13", "3.14", true},
+		{"/1e3", "1e3", true},
+		{"/1E3x", "1E3", true},
+		{"/2.5e-3", "2.5e-3", true},
+		{"/2.5e-x", "2.5", true},
+		{"/6e", "6", true},
+		{"/1e3.5", "1e3", true},
+		{"/1e3e4", "1e3", true},
+		{"/1E3E4", "1E3", true},
+		{"/1e-3e4", "1e-3", true},
+		{"/1ee3", "1", true},
+		{"/+1.5", "+1.5", true},
+		{"/+.5", "+.5", true},
+		{"/+1e3", "+1e3", true},
+		{"/1+2", "1", true},
+		{"/1.5+2", "1.5", true},
+		{"/a+b5", "5", true},
+		{"/+x.5", ".5", true},
+		{"/-0.0", "-0.0", true},
+		{"/-0", "-0", true},
+		{"/0e0", "0e0", true},
 		{"/error", "", false},
 		{"/.", "", false},
+		{"/café1.5", "1.5", true},
+		{"/١٢٣", "", false},
+		{"/²³.¹", "", false},
 	}
 
 	for _, tt := range tests {
@@ -39,6 +62,37 @@ func TestUnitFirstFloatFromString(t *testing.T) {
 	}
 }
 
+func TestUnitFirstFloatFromStringSep(t *testing.T) {
+	tests := []struct {
+		s      string
+		sep    byte
+		want   string
+		okWant bool
+	}{
+		{"/0,1", ',', "0,1", true},
+		{"/a1,3,4", ',', "1,3", true},
+		{"/,7.0", ',', ",7", true},
+		{"/7.5", ',', "7", true},
+		{"/-9,5", ',', "-9,5", true},
+		{"/3,14e+11", ',', "3,14e+11", true},
+		{"/,", ',', "", false},
+		{"/.", ',', "", false},
+		{"/.", '.', "", false},
+	}
+
+	for _, tt := range tests {
+		got, okGot := firstFloatFromStringSep(tt.s, tt.sep)
+		if okGot != tt.okWant {
+			t.Errorf(gwxFmt, tt.s, okGot, tt.okWant)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.s, got, tt.want)
+		}
+	}
+}
+
 func TestUnitFirstIntFromString(t *testing.T) {
 	var tests = []struct {
 		s      string
@@ -61,6 +115,9 @@ func TestUnitFirstIntFromString(t *testing.T) {
 		{"18446744073709551615", "18446744073709551615", true},
 		{".", "", false},
 		{"error", "", false},
+		{"café-2", "-2", true},
+		{"١٢٣", "", false},
+		{"²³", "", false},
 	}
 
 	for _, tt := range tests {
@@ -98,6 +155,9 @@ func TestUnitFirstUintFromString(t *testing.T) {
 		{"18446744073709551615", "18446744073709551615", true},
 		{".", "", false},
 		{"error", "", false},
+		{"café3", "3", true},
+		{"١٢٣", "", false},
+		{"²³", "", false},
 	}
 
 	for _, tt := range tests {
@@ -112,3 +172,102 @@ func TestUnitFirstUintFromString(t *testing.T) {
 		}
 	}
 }
+
+func TestUnitLastIntToken(t *testing.T) {
+	var tests = []struct {
+		s      string
+		signed bool
+		want   string
+		okWant bool
+	}{
+		{"v1-build-42", true, "42", true},
+		{"v1-build-42", false, "42", true},
+		{"build.-42", true, "-42", true},
+		{"-42", true, "-42", true},
+		{"1-2", true, "2", true},
+		{"1.2.3", true, "1", true},
+		{"1.25", true, "1", true},
+		{"ver1.2", true, "1", true},
+		{"build-2.5", true, "-2", true},
+		{"x-5", true, "-5", true},
+		{"x-5", false, "5", true},
+		{"1.x.5", true, "0", true},
+		{"42", true, "42", true},
+		{"7-", true, "7", true},
+		{"x.y5", true, "5", true},
+		{"-", true, "", false},
+		{"error", true, "", false},
+	}
+
+	for _, tt := range tests {
+		got, okGot := lastIntToken(tt.s, tt.signed)
+		if okGot != tt.okWant {
+			t.Errorf(gwxFmt, tt.s, okGot, tt.okWant)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestUnitIntTokenIndex(t *testing.T) {
+	var tests = []struct {
+		s      string
+		signed bool
+		want   string
+		okWant bool
+	}{
+		{"id-42x7", true, "-42", true},
+		{"id-42x7", false, "42", true},
+		{"a-b5", true, "5", true},
+		{"- 5", true, "5", true},
+		{"--5", true, "-5", true},
+		{"-", true, "", false},
+		{"", true, "", false},
+	}
+
+	for _, tt := range tests {
+		f, l, okGot := intTokenIndex(tt.s, 0, tt.signed, isDigit)
+		if okGot != tt.okWant {
+			t.Errorf(gwxFmt, tt.s, okGot, tt.okWant)
+			continue
+		}
+
+		if got := tt.s[f:l]; got != tt.want {
+			t.Errorf(gwxFmt, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestUnitFloatFromString(t *testing.T) {
+	var tests = []struct {
+		s      string
+		m      NumberMode
+		want   string
+		okWant bool
+	}{
+		{"2.5x7.25", NumberFirst, "2.5", true},
+		{"2.5x7.25", NumberLast, "7.25", true},
+		{"1e3-", NumberLast, "1e3", true},
+		{"1.2.3", NumberLast, ".3", true},
+		{"v.-", NumberLast, "", false},
+		{"v1-rate-2.5", NumberLast, "2.5", true},
+		{"rate=-2.5", NumberLast, "-2.5", true},
+		{"x-5", NumberLast, "-5", true},
+		{"build-2.5", NumberLast, "-2.5", true},
+	}
+
+	for _, tt := range tests {
+		got, okGot := floatFromString(tt.s, tt.m)
+		if okGot != tt.okWant {
+			t.Errorf(gwxFmt, tt.s, okGot, tt.okWant)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.s, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package parth
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SpanN is similar to Span, but returns the n path segments beginning with the
+// segment indicated by the index i. If the index is negative, the negative
+// count begins with the last segment. A count of 0 results in an empty string.
+// An error is returned if: 1. The index is out of range of the path; 2. Fewer
+// than n segments are available, in which case the error wraps
+// ErrLastSegNotFound and reports the number available; 3. The count n is
+// negative, in which case the error wraps ErrSegCountInvalid.
+func SpanN(path string, i, n int) (string, error) {
+	if n < 0 {
+		return "", fmt.Errorf("%w: %d", ErrSegCountInvalid, n)
+	}
+
+	ct := segCount(path)
+	avail := ct - i
+	if i < 0 {
+		avail = -i
+	}
+	if avail <= 0 || avail > ct {
+		return "", ErrFirstSegNotFound
+	}
+
+	if n > avail {
+		return "", fmt.Errorf("%w: %d requested, %d available", ErrLastSegNotFound, n, avail)
+	}
+
+	if n == 0 {
+		return "", nil
+	}
+
+	j := i + n
+	if i < 0 && j == 0 {
+		return Span(path, i, 0)
+	}
+
+	return Span(path, i, j)
+}
+
+// SpanClamped is similar to Span, but clamps out of range indexes to the
+// bounds of the path rather than returning an error (e.g. "/a/b/c" with
+// indexes -9 and 9 results in "/a/b/c"). If the clamped first index does not
+// precede the clamped last index, an empty string is returned. An error is
+// returned only if the path has no segments.
+func SpanClamped(path string, i, j int) (string, error) {
+	ct := segCount(path)
+	if ct == 0 {
+		return "", ErrFirstSegNotFound
+	}
+
+	if i < 0 {
+		i += ct
+	}
+	if j <= 0 {
+		j += ct
+	}
+
+	i = clampInt(i, 0, ct)
+	j = clampInt(j, 0, ct)
+	if i >= j {
+		return "", nil
+	}
+
+	if j == ct {
+		j = 0
+	}
+
+	return Span(path, i, j)
+}
+
+func clampInt(n, min, max int) int {
+	if n < min {
+		return min
+	}
+	if n > max {
+		return max
+	}
+
+	return n
+}
+
+// SpanBetween is similar to Span, but returns the path segments strictly
+// between the segments indicated by the indexes i and j (i.e. both are
+// excluded). If an index is negative, the negative count begins with the last
+// segment. If no segments lie between the two, an empty string is returned. An
+// error is returned if: 1. Either index is out of range of the path; 2. The
+// first index i does not precede or equal the last index j.
+func SpanBetween(path string, i, j int) (string, error) {
+	ct := segCount(path)
+	if i < 0 {
+		i += ct
+	}
+	if j < 0 {
+		j += ct
+	}
+
+	if i < 0 || i >= ct {
+		return "", ErrFirstSegNotFound
+	}
+	if j < 0 || j >= ct {
+		return "", ErrLastSegNotFound
+	}
+	if i > j {
+		return "", ErrSegOrderReversed
+	}
+
+	if j-i < 2 {
+		return "", nil
+	}
+
+	return Span(path, i+1, j)
+}
+
+// SpanToSegmentsReverse is similar to Span, but returns the path segments of
+// the span in reverse order (e.g. "/a/b/c" with indexes 0 and 0 results in
+// [c b a]), which suits building leaf-to-root ancestor chains. Indexes are
+// handled exactly as with Span, and an empty span results in an empty slice.
+func SpanToSegmentsReverse(path string, i, j int) ([]string, error) {
+	s, err := Span(path, i, j)
+	if err != nil {
+		return nil, err
+	}
+
+	if s == "" {
+		return []string{}, nil
+	}
+
+	if s[0] == '/' {
+		s = s[1:]
+	}
+
+	segs := strings.Split(s, "/")
+	for l, r := 0, len(segs)-1; l < r; l, r = l+1, r-1 {
+		segs[l], segs[r] = segs[r], segs[l]
+	}
+
+	return segs, nil
+}
+
+// Remainder returns all of the path after the segment indicated by the index
+// afterSeg, including any internal slashes (e.g. "/files/docs/2023/a.pdf" with
+// an afterSeg of 0 results in "docs/2023/a.pdf"). If the index is negative,
+// the negative count begins with the last segment. If slash is true, the
+// result is prefixed with a slash. If the index is that of the last segment
+// or beyond it, an empty string is returned. An error is returned if a
+// negative index is out of range of the path.
+func Remainder(path string, afterSeg int, slash bool) (string, error) {
+	var f int
+	var ok bool
+
+	if afterSeg < 0 {
+		_, ok = segStartIndexFromEnd(path, afterSeg)
+		if ok {
+			f = len(path)
+			if afterSeg < -1 {
+				f, _ = segStartIndexFromEnd(path, afterSeg+1)
+			}
+		}
+	} else {
+		if f, ok = segStartIndexFromStart(path, afterSeg+1); !ok {
+			f = len(path)
+		}
+		ok = true
+	}
+	if !ok {
+		return "", ErrFirstSegNotFound
+	}
+
+	s := path[f:]
+	if s != "" && s[0] == '/' {
+		s = s[1:]
+	}
+
+	if slash {
+		return "/" + s, nil
+	}
+
+	return s, nil
+}
@@ -0,0 +1,298 @@
+package parth
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBhvrSpanN(t *testing.T) {
+	path := "/zero/one/two/three/four"
+
+	tests := []struct {
+		name string
+		path string
+		i, n int
+		want string
+		ck   checkFunc
+	}{
+		{"5 segs: +1,2", path, 1, 2, "/one/two", unx},
+		{"5 segs: 00,5", path, 0, 5, path, unx},
+		{"5 segs: +4,1", path, 4, 1, "/four", unx},
+		{"5 segs: -2,2", path, -2, 2, "/three/four", unx},
+		{"5 segs: -3,1", path, -3, 1, "/two", unx},
+		{"5 segs: -5,5", path, -5, 5, path, unx},
+		{"5 segs: +1,0", path, 1, 0, "", unx},
+		{"5 segs: 00,0", path, 0, 0, "", unx},
+		{"5 segs: -1,0", path, -1, 0, "", unx},
+		{"5 segs: +5,0", path, 5, 0, "", exp},
+		{"5 segs: +3,3", path, 3, 3, "", exp},
+		{"5 segs: -2,3", path, -2, 3, "", exp},
+		{"5 segs: +5,1", path, 5, 1, "", exp},
+		{"5 segs: -6,1", path, -6, 1, "", exp},
+		{"5 segs: +1,-1", path, 1, -1, "", exp},
+		{"3 no /: 00,2", "zero/one/two", 0, 2, "zero/one", unx},
+	}
+
+	for _, tt := range tests {
+		got, err := SpanN(tt.path, tt.i, tt.n)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("message", func(t *testing.T) {
+		_, err := SpanN(path, 3, 3)
+
+		want := "last segment not found by index: 3 requested, 2 available"
+		if err == nil || err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+	})
+
+	t.Run("negative count", func(t *testing.T) {
+		_, err := SpanN(path, 1, -1)
+		if !errors.Is(err, ErrSegCountInvalid) {
+			t.Fatalf(gwFmt, err, ErrSegCountInvalid)
+		}
+
+		want := "segment count is invalid: -1"
+		if err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+	})
+}
+
+func TestBhvrRemainder(t *testing.T) {
+	path := "/files/docs/2023/report.pdf"
+
+	tests := []struct {
+		name  string
+		path  string
+		i     int
+		slash bool
+		want  string
+		ck    checkFunc
+	}{
+		{"after 0", path, 0, false, "docs/2023/report.pdf", unx},
+		{"after 0 slash", path, 0, true, "/docs/2023/report.pdf", unx},
+		{"after 2", path, 2, false, "report.pdf", unx},
+		{"after last", path, 3, false, "", unx},
+		{"after last slash", path, 3, true, "/", unx},
+		{"after -2", path, -2, false, "report.pdf", unx},
+		{"after -4", path, -4, false, "docs/2023/report.pdf", unx},
+		{"after -1", path, -1, false, "", unx},
+		{"trailing slash", "/files/docs/", 0, false, "docs/", unx},
+		{"no leading slash", "files/docs", 0, true, "/docs", unx},
+		{"beyond last", path, 4, false, "", unx},
+		{"far beyond last", path, 9, false, "", unx},
+		{"beyond last slash", path, 4, true, "/", unx},
+		{"neg out of range", path, -5, false, "", exp},
+		{"empty path", "", 0, false, "", unx},
+		{"empty path neg", "", -1, false, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := Remainder(tt.path, tt.i, tt.slash)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSpanBetween(t *testing.T) {
+	path := "/zero/one/two/three"
+
+	tests := []struct {
+		name string
+		path string
+		i, j int
+		want string
+		ck   checkFunc
+	}{
+		{"4 segs: 00,+3", path, 0, 3, "/one/two", unx},
+		{"4 segs: 00,+2", path, 0, 2, "/one", unx},
+		{"4 segs: 00,+1", path, 0, 1, "", unx},
+		{"4 segs: +1,+1", path, 1, 1, "", unx},
+		{"4 segs: -4,-1", path, -4, -1, "/one/two", unx},
+		{"4 segs: 00,-1", path, 0, -1, "/one/two", unx},
+		{"4 segs: -3,+3", path, -3, 3, "/two", unx},
+		{"4 segs: +3,+1", path, 3, 1, "", exp},
+		{"4 segs: 00,+4", path, 0, 4, "", exp},
+		{"4 segs: -5,+3", path, -5, 3, "", exp},
+		{"3 no /: 00,+2", "zero/one/two", 0, 2, "/one", unx},
+	}
+
+	for _, tt := range tests {
+		got, err := SpanBetween(tt.path, tt.i, tt.j)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSpanSingleNegative(t *testing.T) {
+	path := "/a/b/c"
+
+	tests := []struct {
+		name string
+		i    int
+		want string
+	}{
+		{"-1", -1, "/c"},
+		{"-2", -2, "/b"},
+		{"-3", -3, "/a"},
+	}
+
+	for _, tt := range tests {
+		// the last index of Span is exclusive, so equal indexes are empty
+		got, err := Span(path, tt.i, tt.i)
+		if unx(t, tt.name, err) {
+			continue
+		}
+
+		if got != "" {
+			t.Errorf(gwxFmt, tt.name, got, "")
+		}
+
+		j := tt.i + 1
+		got, err = Span(path, tt.i, j)
+		if unx(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+
+		got, err = SpanN(path, tt.i, 1)
+		if unx(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSpanToSegmentsReverse(t *testing.T) {
+	path := "/a/b/c/d"
+
+	tests := []struct {
+		name string
+		path string
+		i, j int
+		want []string
+		ck   checkFunc
+	}{
+		{"all", path, 0, 0, []string{"d", "c", "b", "a"}, unx},
+		{"middle", path, 1, 3, []string{"c", "b"}, unx},
+		{"negative", path, -3, -1, []string{"c", "b"}, unx},
+		{"negative to end", path, -2, 0, []string{"d", "c"}, unx},
+		{"single", path, 2, 3, []string{"c"}, unx},
+		{"trailing slash", "/a/b/", 0, 0, []string{"", "b", "a"}, unx},
+		{"no leading slash", "a/b", 0, 0, []string{"b", "a"}, unx},
+		{"empty span", path, 2, 2, []string{}, unx},
+		{"reversed", path, 3, 1, nil, exp},
+		{"out of range", path, 0, 9, nil, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SpanToSegmentsReverse(tt.path, tt.i, tt.j)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSpanClamped(t *testing.T) {
+	path := "/a/b/c"
+
+	tests := []struct {
+		name string
+		path string
+		i, j int
+		want string
+		ck   checkFunc
+	}{
+		{"in range", path, 1, 2, "/b", unx},
+		{"to end", path, 1, 0, "/b/c", unx},
+		{"last beyond", path, 1, 9, "/b/c", unx},
+		{"first before", path, -9, 2, "/a/b", unx},
+		{"both beyond", path, -9, 9, "/a/b/c", unx},
+		{"negative last", path, 0, -1, "/a/b", unx},
+		{"negative last before", path, 0, -9, "", unx},
+		{"first beyond", path, 5, 0, "", unx},
+		{"reversed", path, 2, 1, "", unx},
+		{"no leading slash", "a/b", -9, 9, "a/b", unx},
+		{"root", "/", 0, 9, "/", unx},
+		{"empty", "", 0, 0, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SpanClamped(tt.path, tt.i, tt.j)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSpanNegativeFirst(t *testing.T) {
+	path := "/a/b/c/d"
+
+	tests := []struct {
+		name string
+		path string
+		i, j int
+		want string
+		ck   checkFunc
+	}{
+		{"last three", path, -3, 0, "/b/c/d", unx},
+		{"last three exclusive", path, -3, -1, "/b/c", unx},
+		{"all", path, -4, 0, path, unx},
+		{"all but last", path, -4, -1, "/a/b/c", unx},
+		{"last", path, -1, 0, "/d", unx},
+		{"penultimate", path, -2, -1, "/c", unx},
+		{"positive last", path, -3, 3, "/b/c", unx},
+		{"positive last end", path, -3, 4, "/b/c/d", unx},
+		{"positive first seg", path, -4, 1, "/a", unx},
+		{"equal", path, -2, 2, "", unx},
+		{"no leading slash", "a/b/c/d", -3, 0, "/b/c/d", unx},
+		{"no leading slash first", "a/b/c/d", -4, -1, "a/b/c", unx},
+		{"first beyond", path, -5, 0, "", exp},
+		{"reversed", path, -1, 2, "", exp},
+		{"reversed negative", path, -1, -2, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := Span(tt.path, tt.i, tt.j)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,632 @@
+package parth
+
+import (
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+// SegmentToStringTrimmed locates the path segment indicated by the index i and
+// returns it with all leading and trailing characters contained in cutset
+// removed. If cutset is empty, leading and trailing white space is removed
+// instead. A segment that is trimmed away entirely results in an empty string
+// (not an error). An error is returned if the index is out of range of the
+// path.
+func SegmentToStringTrimmed(path string, i int, cutset string) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	if cutset == "" {
+		return strings.TrimSpace(s), nil
+	}
+
+	return strings.Trim(s, cutset), nil
+}
+
+// SegmentToStringCollapseSpaces locates the path segment indicated by the index
+// i and returns it with leading and trailing white space removed and each
+// internal run of white space replaced by a single space (e.g. " a \t b "
+// results in "a b"). A segment consisting only of white space results in an
+// empty string (not an error). An error is returned if the index is out of
+// range of the path.
+func SegmentToStringCollapseSpaces(path string, i int) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join(strings.Fields(s), " "), nil
+}
+
+// SegmentToStringReverse locates the path segment indicated by the index i and
+// returns it with its runes in reverse order (e.g. "abc" results in "cba").
+// Runes, rather than bytes, are reversed so that multibyte characters remain
+// intact; invalid UTF-8 is replaced by utf8.RuneError. An error is returned if
+// the index is out of range of the path.
+func SegmentToStringReverse(path string, i int) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	rs := []rune(s)
+	for f, l := 0, len(rs)-1; f < l; f, l = f+1, l-1 {
+		rs[f], rs[l] = rs[l], rs[f]
+	}
+
+	return string(rs), nil
+}
+
+// SegmentToStringLeftPad locates the path segment indicated by the index i and
+// returns it preceded by enough pad runes to make it width runes long (e.g.
+// "42" with a width of 5 and a pad of '0' results in "00042"). A segment that
+// is already at least width runes long is returned unchanged. An error is
+// returned if the index is out of range of the path.
+func SegmentToStringLeftPad(path string, i, width int, pad rune) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	return padding(s, width, pad) + s, nil
+}
+
+// SegmentToStringRightPad is similar to SegmentToStringLeftPad, but the pad
+// runes follow the segment.
+func SegmentToStringRightPad(path string, i, width int, pad rune) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	return s + padding(s, width, pad), nil
+}
+
+func padding(s string, width int, pad rune) string {
+	ct := width - utf8.RuneCountInString(s)
+	if ct <= 0 {
+		return ""
+	}
+
+	return strings.Repeat(string(pad), ct)
+}
+
+// SegmentToStringLower locates the path segment indicated by the index i and
+// returns it with all Unicode letters mapped to their lower case. A segment
+// that is already lower case is returned without allocating. An error is
+// returned if the index is out of range of the path.
+func SegmentToStringLower(path string, i int) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.ToLower(s), nil
+}
+
+// SegmentToStringUpper is similar to SegmentToStringLower, but maps letters to
+// their upper case.
+func SegmentToStringUpper(path string, i int) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.ToUpper(s), nil
+}
+
+// SegmentToStringSnake locates the path segment indicated by the index i and
+// returns it converted from camelCase or PascalCase to snake_case (e.g.
+// "UserAccount" results in "user_account"). A run of upper case letters is
+// treated as a single word, with its final letter beginning the next word when
+// followed by a lower case letter (e.g. "HTTPServer" results in "http_server").
+// A segment that is already snake_case is returned unchanged. An error is
+// returned if the index is out of range of the path.
+func SegmentToStringSnake(path string, i int) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	rs := []rune(s)
+	var b strings.Builder
+	b.Grow(len(s) + 4)
+
+	for n, r := range rs {
+		if n > 0 && unicode.IsUpper(r) {
+			prev := rs[n-1]
+			nextLower := n+1 < len(rs) && unicode.IsLower(rs[n+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	return b.String(), nil
+}
+
+// SegmentToSlug locates the path segment indicated by the index i and returns
+// it normalized as a URL slug: letters are lower cased, each run of spaces,
+// underscores, and dashes becomes a single dash, and all other characters,
+// including letters and digits that are not ASCII, are dropped (e.g. "Hello
+// World!" results in "hello-world"). Dashes do not begin or end the slug. An
+// error is returned if: 1. The index is out of range of the path; 2. Nothing
+// remains of the located path segment, in which case the error wraps
+// ErrEmptySegment.
+func SegmentToSlug(path string, i int) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+
+	dash := false
+	for n := 0; n < len(s); n++ {
+		c := s[n]
+		switch {
+		case 'A' <= c && c <= 'Z':
+			c += 'a' - 'A'
+		case 'a' <= c && c <= 'z', isDigit(c):
+		case c == ' ' || c == '_' || c == '-':
+			dash = b.Len() > 0
+			continue
+		default:
+			continue
+		}
+
+		if dash {
+			b.WriteByte('-')
+			dash = false
+		}
+		b.WriteByte(c)
+	}
+
+	if b.Len() == 0 {
+		return "", fmt.Errorf("%w: no slug characters in %q", ErrEmptySegment, s)
+	}
+
+	return b.String(), nil
+}
+
+// ValidateSlug reports whether the path segment indicated by the index i is
+// already a clean slug, as produced by SegmentToSlug: one or more words of
+// lower case ASCII letters and digits, separated by single dashes. A nil error
+// is returned when the segment is a slug. Otherwise, an error wrapping
+// ErrDataUnparsable is returned, or the index error if the index is out of
+// range of the path.
+func ValidateSlug(path string, i int) error {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return err
+	}
+
+	if !isSlug(s) {
+		return fmt.Errorf("%w: %q is not a slug", ErrDataUnparsable, s)
+	}
+
+	return nil
+}
+
+func isSlug(s string) bool {
+	if s == "" || s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+
+	for n := 0; n < len(s); n++ {
+		c := s[n]
+		switch {
+		case 'a' <= c && c <= 'z', isDigit(c):
+		case c == '-' && s[n-1] != '-':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// SegmentToStringOk locates the path segment indicated by the index i and
+// returns it along with whether it exists. An empty segment (e.g. index 1 of
+// "/a//b") exists, so ("", true) is returned for it, while ("", false) is
+// returned when the index is out of range of the path.
+func SegmentToStringOk(path string, i int) (string, bool) {
+	s, err := segmentToString(path, i)
+	return s, err == nil
+}
+
+// SegmentToStringEmptyOr locates the path segment indicated by the index i and
+// returns it, or returns def if the segment is empty (e.g. index 1 of "/a//b")
+// or the index is out of range of the path. See SegmentToStringOk for
+// distinguishing a missing segment from an empty one.
+func SegmentToStringEmptyOr(path string, i int, def string) string {
+	if s, err := segmentToString(path, i); err == nil && s != "" {
+		return s
+	}
+
+	return def
+}
+
+// SegmentToStringEscaped locates the path segment indicated by the index i and
+// returns it escaped using url.PathEscape, so that the result can be safely
+// placed within another path as a single segment. Note that this encodes the
+// segment data; it does not decode it (see url.PathUnescape). An error is
+// returned if the index is out of range of the path.
+func SegmentToStringEscaped(path string, i int) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	return url.PathEscape(s), nil
+}
+
+// SegmentToStringQueryUnescaped locates the path segment indicated by the index
+// i and decodes it using url.QueryUnescape, for clients that apply query
+// encoding to path segments. Unlike url.PathUnescape, which leaves '+' as-is,
+// each '+' is decoded as a space (e.g. "a+b%2Bc" results in "a b+c"). An error
+// is returned if: 1. The index is out of range of the path; 2. The located path
+// segment cannot be decoded, in which case the decode error is returned.
+func SegmentToStringQueryUnescaped(path string, i int) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	return url.QueryUnescape(s)
+}
+
+// SegmentToStringTransform locates the path segment indicated by the index i
+// and returns the result of passing it to fn. If fn is nil, the segment is
+// returned as-is. An error is returned if: 1. The index is out of range of the
+// path, in which case the index error is returned unchanged; 2. fn returns an
+// error, in which case it is wrapped along with the index.
+func SegmentToStringTransform(path string, i int, fn func(string) (string, error)) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil || fn == nil {
+		return s, err
+	}
+
+	v, err := fn(s)
+	if err != nil {
+		return "", fmt.Errorf("transform segment %d: %w", i, err)
+	}
+
+	return v, nil
+}
+
+// SegmentToStringMaxLen locates the path segment indicated by the index i and
+// returns it if its length does not exceed max bytes. An error wrapping
+// ErrSegTooLong is returned if the segment is longer than max, and an error is
+// returned if the index is out of range of the path.
+func SegmentToStringMaxLen(path string, i, max int) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	if len(s) > max {
+		return "", fmt.Errorf("%w: %d bytes, limit is %d", ErrSegTooLong, len(s), max)
+	}
+
+	return s, nil
+}
+
+// SegmentToStringExactLen locates the path segment indicated by the index i and
+// returns it if its length is exactly length bytes, as with fixed-width codes.
+// Multibyte characters count as more than one byte, so length should account
+// for the encoded size of codes that are not ASCII. An error wrapping
+// ErrSegWrongLen that reports the actual and expected lengths is returned if
+// the lengths differ, and an error is returned if the index is out of range of
+// the path.
+func SegmentToStringExactLen(path string, i, length int) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	if len(s) != length {
+		return "", fmt.Errorf("%w: %d bytes, want %d", ErrSegWrongLen, len(s), length)
+	}
+
+	return s, nil
+}
+
+// SegmentToNonEmptyString locates the path segment indicated by the index i and
+// returns it if it is not empty. ErrEmptySegment is returned if the segment
+// exists but is empty (e.g. index 1 of "/a//b"), and an error wrapping
+// ErrFirstSegNotFound is returned if the index is out of range of the path.
+func SegmentToNonEmptyString(path string, i int) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	if s == "" {
+		return "", ErrEmptySegment
+	}
+
+	return s, nil
+}
+
+// SegmentToStringTrimPrefix locates the path segment indicated by the index i
+// and returns it without the provided leading prefix (e.g. "user_42" with
+// prefix "user_" results in "42"). If the segment does not begin with prefix,
+// it is returned unchanged. An error is returned if the index is out of range
+// of the path.
+func SegmentToStringTrimPrefix(path string, i int, prefix string) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimPrefix(s, prefix), nil
+}
+
+// SegmentToStringTrimSuffix is similar to SegmentToStringTrimPrefix, but
+// removes the provided trailing suffix.
+func SegmentToStringTrimSuffix(path string, i int, suffix string) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(s, suffix), nil
+}
+
+// SegmentToStringCut locates the path segment indicated by the index i and
+// slices it around the first instance of sep in the manner of strings.Cut
+// (e.g. "name=bob" with sep "=" results in "name", "bob", and true). If sep is
+// not found, before holds the whole segment and found is false. An error is
+// returned if the index is out of range of the path.
+func SegmentToStringCut(path string, i int, sep string) (before, after string, found bool, err error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", "", false, err
+	}
+
+	before, after, found = strings.Cut(s, sep)
+	return before, after, found, nil
+}
+
+// SegmentToStringList locates the path segment indicated by the index i and
+// splits it around each instance of sep (e.g. "a,b,c" with sep "," results in
+// [a b c]). Empty trailing elements are removed, so an empty segment results in
+// an empty slice. An error is returned if the index is out of range of the
+// path.
+func SegmentToStringList(path string, i int, sep string) ([]string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return nil, err
+	}
+
+	ss := strings.Split(s, sep)
+	for len(ss) > 0 && ss[len(ss)-1] == "" {
+		ss = ss[:len(ss)-1]
+	}
+
+	return ss, nil
+}
+
+// SegmentToStringByteRange locates the path segment indicated by the index i
+// and returns length bytes of it beginning at the byte offset start (e.g.
+// "20230115ABC" with start 0 and length 8 results in "20230115"). If start is
+// negative, the offset is counted from the end of the segment. An error is
+// returned if: 1. The index is out of range of the path; 2. The requested range
+// does not lie within the located path segment, in which case the error wraps
+// ErrDataUnparsable.
+func SegmentToStringByteRange(path string, i, start, length int) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	f := start
+	if f < 0 {
+		f += len(s)
+	}
+
+	if f < 0 || length < 0 || length > len(s)-f {
+		return "", fmt.Errorf("%w: byte range %d+%d exceeds segment length %d", ErrDataUnparsable, start, length, len(s))
+	}
+
+	return s[f : f+length], nil
+}
+
+// SegmentToStringFirstOf returns the first non-empty path segment indicated by
+// the provided indexes, which are tried in the order given. Indexes that are
+// out of range of the path or that locate an empty segment are skipped. If no
+// index results in a non-empty segment, an error wrapping ErrFirstSegNotFound
+// that lists the indexes tried is returned.
+func SegmentToStringFirstOf(path string, indexes ...int) (string, error) {
+	for _, i := range indexes {
+		if s, err := segmentToString(path, i); err == nil && s != "" {
+			return s, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: no non-empty segment at %v", ErrFirstSegNotFound, indexes)
+}
+
+// SegmentToStringFrom locates the path segment indicated by the index i within
+// primary and returns it. If the index is out of range of primary, the segment
+// is located within fallback instead (e.g. "/a" and "/x/y/z" with index 2
+// results in "z"). An error naming both paths is returned if the index is out
+// of range of each.
+func SegmentToStringFrom(primary, fallback string, i int) (string, error) {
+	if s, err := segmentToString(primary, i); err == nil {
+		return s, nil
+	}
+
+	s, err := segmentToString(fallback, i)
+	if err != nil {
+		return "", fmt.Errorf("%w: tried %q and %q", err, primary, fallback)
+	}
+
+	return s, nil
+}
+
+// SegmentToStringReplacer locates the path segment indicated by the index i and
+// returns it with the replacements of r applied (e.g. r created by
+// strings.NewReplacer("_", " ") results in "a b" for "a_b"). If r is nil, the
+// segment is returned as-is. An error is returned if the index is out of range
+// of the path.
+func SegmentToStringReplacer(path string, i int, r *strings.Replacer) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil || r == nil {
+		return s, err
+	}
+
+	return r.Replace(s), nil
+}
+
+// SegmentTrimExtension locates the path segment indicated by the index i and
+// returns it without its file extension, which begins at the final dot (e.g.
+// "report.json" results in "report" and "a.tar.gz" in "a.tar"). A segment
+// without a dot, or with only a leading dot (e.g. ".gitignore"), is returned
+// unchanged. An error is returned if the index is out of range of the path.
+func SegmentTrimExtension(path string, i int) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	if n := strings.LastIndexByte(s, '.'); n > 0 {
+		return s[:n], nil
+	}
+
+	return s, nil
+}
+
+// SegmentToStringHashed locates the path segment indicated by the index i and
+// returns the hex encoded digest of it as produced by a hash.Hash from h (e.g.
+// sha256.New). An empty segment results in the digest of empty input. An error
+// is returned if the index is out of range of the path.
+func SegmentToStringHashed(path string, i int, h func() hash.Hash) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	hh := h()
+	_, _ = io.WriteString(hh, s)
+
+	return hex.EncodeToString(hh.Sum(nil)), nil
+}
+
+// SegmentReader locates the path segment indicated by the index i and returns
+// a *strings.Reader over it, so that the segment data can be consumed by APIs
+// that accept an io.Reader (e.g. base64.NewDecoder). An error is returned if
+// the index is out of range of the path.
+func SegmentReader(path string, i int) (io.Reader, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.NewReader(s), nil
+}
+
+// SegmentToStringNFC locates the path segment indicated by the index i and
+// returns it normalized to Unicode Normalization Form C, so that canonically
+// equivalent segments (e.g. "e\u0301" and "\u00e9") compare as equal. An error
+// is returned if the index is out of range of the path.
+func SegmentToStringNFC(path string, i int) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	return norm.NFC.String(s), nil
+}
+
+// SegmentToStringNFKC is similar to SegmentToStringNFC, but normalizes to
+// Unicode Normalization Form KC, which also folds compatibility variants (e.g.
+// "\ufb01" to "fi").
+func SegmentToStringNFKC(path string, i int) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	return norm.NFKC.String(s), nil
+}
+
+// SegmentToStringTitle locates the path segment indicated by the index i and
+// returns it title cased using Unicode word boundaries, so that the first
+// letter of each word is upper case and the rest are lower case (e.g. "hELLO
+// wORLD" results in "Hello World"). A dash separates words, while an
+// underscore does not (e.g. "my-first_post" results in "My-First_post"). An
+// error is returned if the index is out of range of the path.
+func SegmentToStringTitle(path string, i int) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	return cases.Title(language.Und).String(s), nil
+}
+
+// SegmentToStringTitleWords is similar to SegmentToStringTitle, but dashes and
+// underscores are replaced by spaces before title casing, which suits slug
+// segments (e.g. "my-first_post" results in "My First Post").
+func SegmentToStringTitleWords(path string, i int) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	s = strings.NewReplacer("-", " ", "_", " ").Replace(s)
+
+	return cases.Title(language.Und).String(s), nil
+}
+
+// SegmentToStringUnquoted locates the path segment indicated by the index i and
+// returns it without its surrounding quotes. Double quoted segments are
+// unquoted using strconv.Unquote, so escape sequences such as \t are
+// processed, while single quoted segments simply have their quotes removed. A
+// segment that is not quoted is returned unchanged. An error is returned if: 1.
+// The index is out of range of the path; 2. The located path segment has
+// mismatched or dangling quotes or invalid escape sequences, in which case the
+// error wraps ErrDataUnparsable.
+func SegmentToStringUnquoted(path string, i int) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	isQuote := func(c byte) bool { return c == '"' || c == '\'' }
+
+	if s == "" || !isQuote(s[0]) && !isQuote(s[len(s)-1]) {
+		return s, nil
+	}
+
+	if len(s) < 2 || s[0] != s[len(s)-1] {
+		return "", fmt.Errorf("%w: mismatched quotes in %q", ErrDataUnparsable, s)
+	}
+
+	if s[0] == '\'' {
+		return s[1 : len(s)-1], nil
+	}
+
+	v, err := strconv.Unquote(s)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q: %v", ErrDataUnparsable, s, err)
+	}
+
+	return v, nil
+}
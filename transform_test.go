@@ -0,0 +1,1169 @@
+package parth
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"hash"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBhvrSegmentToStringTrimmed(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		i      int
+		cutset string
+		want   string
+		ck     checkFunc
+	}{
+		{"parens", "/item/(42)/", 1, "()", "42", unx},
+		{"one side", "/item/42)", 1, "()", "42", unx},
+		{"whitespace default", "/item/ \t42 ", 1, "", "42", unx},
+		{"untouched", "/item/42", 1, "()", "42", unx},
+		{"trimmed away", "/item/(())", 1, "()", "", unx},
+		{"bad index", "/item/(42)", 2, "()", "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringTrimmed(tt.path, tt.i, tt.cutset)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToStringCollapseSpaces(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"runs", "/q/ a \t b  c ", 1, "a b c", unx},
+		{"newlines", "/q/a\n\nb", 1, "a b", unx},
+		{"single spaces", "/q/a b", 1, "a b", unx},
+		{"whitespace only", "/q/ \t ", 1, "", unx},
+		{"empty", "/q//", 1, "", unx},
+		{"bad index", "/q", 1, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringCollapseSpaces(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToStringReverse(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"ascii", "/t/abc", 1, "cba", unx},
+		{"multibyte", "/t/ab\u00e9\u4e16", 1, "\u4e16\u00e9ba", unx},
+		{"single", "/t/a", 1, "a", unx},
+		{"empty", "/t//", 1, "", unx},
+		{"bad index", "/t", 1, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringReverse(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToStringLeftPad(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		i     int
+		width int
+		pad   rune
+		want  string
+		ck    checkFunc
+	}{
+		{"pad", "/ids/42", 1, 5, '0', "00042", unx},
+		{"exact", "/ids/12345", 1, 5, '0', "12345", unx},
+		{"longer", "/ids/123456", 1, 5, '0', "123456", unx},
+		{"zero width", "/ids/42", 1, 0, '0', "42", unx},
+		{"multibyte seg", "/ids/\u00e9", 1, 3, '.', "..\u00e9", unx},
+		{"multibyte pad", "/ids/a", 1, 3, '\u00b7', "\u00b7\u00b7a", unx},
+		{"empty", "/ids//", 1, 2, '0', "00", unx},
+		{"bad index", "/ids", 1, 5, '0', "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringLeftPad(tt.path, tt.i, tt.width, tt.pad)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToStringRightPad(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		i     int
+		width int
+		pad   rune
+		want  string
+		ck    checkFunc
+	}{
+		{"pad", "/ids/42", 1, 5, ' ', "42   ", unx},
+		{"longer", "/ids/123456", 1, 5, ' ', "123456", unx},
+		{"zero width", "/ids/42", 1, 0, ' ', "42", unx},
+		{"multibyte seg", "/ids/\u00e9", 1, 3, '.', "\u00e9..", unx},
+		{"bad index", "/ids", 1, 5, ' ', "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringRightPad(tt.path, tt.i, tt.width, tt.pad)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToStringSnake(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"pascal", "/type/UserAccount/", 1, "user_account", unx},
+		{"camel", "/type/userAccount", 1, "user_account", unx},
+		{"leading run", "/type/HTTPServer", 1, "http_server", unx},
+		{"trailing run", "/type/userID", 1, "user_id", unx},
+		{"inner run", "/type/getHTTPResponse", 1, "get_http_response", unx},
+		{"all upper", "/type/ABC", 1, "abc", unx},
+		{"digit", "/type/v2Api", 1, "v2_api", unx},
+		{"snake", "/type/user_account", 1, "user_account", unx},
+		{"snake upper", "/type/user_Account", 1, "user_account", unx},
+		{"unicode", "/type/\u00e9t\u00e9Fin", 1, "\u00e9t\u00e9_fin", unx},
+		{"empty", "/type//", 1, "", unx},
+		{"bad index", "/type", 1, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringSnake(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToSlug(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"words", "/post/Hello World!/", 1, "hello-world", unx},
+		{"underscores", "/post/my_first_post", 1, "my-first-post", unx},
+		{"runs", "/post/a  -_ b", 1, "a-b", unx},
+		{"trimmed", "/post/ -a- ", 1, "a", unx},
+		{"digits", "/post/Top 10 Tips", 1, "top-10-tips", unx},
+		{"dropped inner", "/post/don't", 1, "dont", unx},
+		{"non-ascii", "/post/caf\u00e9 ok", 1, "caf-ok", unx},
+		{"clean", "/post/already-clean", 1, "already-clean", unx},
+		{"nothing left", "/post/!?", 1, "", exp},
+		{"empty", "/post//", 1, "", exp},
+		{"bad index", "/post", 1, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToSlug(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("message", func(t *testing.T) {
+		_, err := SegmentToSlug("/post/!?", 1)
+		if !errors.Is(err, ErrEmptySegment) {
+			t.Fatalf(gwFmt, err, ErrEmptySegment)
+		}
+
+		want := `segment is empty: no slug characters in "!?"`
+		if err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+	})
+}
+
+func TestBhvrValidateSlug(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		ck   checkFunc
+	}{
+		{"slug", "/post/hello-world/", 1, unx},
+		{"single word", "/post/hello", 1, unx},
+		{"digits", "/post/top-10", 1, unx},
+		{"upper", "/post/Hello-world", 1, exp},
+		{"space", "/post/hello world", 1, exp},
+		{"underscore", "/post/hello_world", 1, exp},
+		{"double dash", "/post/hello--world", 1, exp},
+		{"leading dash", "/post/-hello", 1, exp},
+		{"trailing dash", "/post/hello-", 1, exp},
+		{"empty", "/post//", 1, exp},
+		{"bad index", "/post", 1, exp},
+	}
+
+	for _, tt := range tests {
+		err := ValidateSlug(tt.path, tt.i)
+		tt.ck(t, tt.name, err)
+	}
+
+	t.Run("message", func(t *testing.T) {
+		err := ValidateSlug("/post/Hello", 1)
+		if !errors.Is(err, ErrDataUnparsable) {
+			t.Fatalf(gwFmt, err, ErrDataUnparsable)
+		}
+
+		want := `data cannot be parsed: "Hello" is not a slug`
+		if err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+	})
+}
+
+func TestBhvrSegmentToStringLower(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"mixed", "/user/BoB", 1, "bob", unx},
+		{"lower", "/user/bob", 1, "bob", unx},
+		{"unicode", "/user/ÇAFÉ", 1, "çafé", unx},
+		{"bad index", "/user/BoB", 2, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringLower(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("allocs", func(t *testing.T) {
+		n := testing.AllocsPerRun(10, func() {
+			_, _ = SegmentToStringLower("/user/bob", 1)
+		})
+		if n != 0 {
+			t.Errorf(gwFmt, n, 0)
+		}
+	})
+}
+
+func TestBhvrSegmentToStringUpper(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"mixed", "/user/BoB", 1, "BOB", unx},
+		{"upper", "/user/BOB", 1, "BOB", unx},
+		{"unicode", "/user/çafé", 1, "ÇAFÉ", unx},
+		{"bad index", "/user/BoB", 2, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringUpper(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("allocs", func(t *testing.T) {
+		n := testing.AllocsPerRun(10, func() {
+			_, _ = SegmentToStringUpper("/user/BOB", 1)
+		})
+		if n != 0 {
+			t.Errorf(gwFmt, n, 0)
+		}
+	})
+}
+
+func TestBhvrSegmentToStringOk(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		i      int
+		want   string
+		okWant bool
+	}{
+		{"present", "/a//b", 0, "a", true},
+		{"empty", "/a//b", 1, "", true},
+		{"trailing empty", "/a/b/", 2, "", true},
+		{"missing", "/a//b", 3, "", false},
+		{"neg last", "/a/b", -1, "b", true},
+		{"neg trailing slash", "/a/b/", -2, "a", true},
+		{"neg missing", "/a//b", -4, "", false},
+	}
+
+	for _, tt := range tests {
+		got, okGot := SegmentToStringOk(tt.path, tt.i)
+		if okGot != tt.okWant {
+			t.Errorf(gwxFmt, tt.name, okGot, tt.okWant)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToStringEmptyOr(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+	}{
+		{"present", "/a/b/c", 1, "b"},
+		{"empty", "/a//c", 1, "def"},
+		{"trailing empty", "/a/b/", 2, "def"},
+		{"missing", "/a", 3, "def"},
+	}
+
+	for _, tt := range tests {
+		got := SegmentToStringEmptyOr(tt.path, tt.i, "def")
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToStringEscaped(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"space and question", "/q/a b?c/", 1, "a%20b%3Fc", unx},
+		{"safe", "/q/abc", 1, "abc", unx},
+		{"percent", "/q/100%", 1, "100%25", unx},
+		{"bad index", "/q/abc", 2, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringEscaped(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToStringQueryUnescaped(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"plus", "/q/a+b%2Bc", 1, "a b+c", unx},
+		{"percent space", "/q/a%20b", 1, "a b", unx},
+		{"plain", "/q/abc", 1, "abc", unx},
+		{"invalid escape", "/q/a%zz", 1, "", exp},
+		{"bad index", "/q", 1, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringQueryUnescaped(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToStringTransform(t *testing.T) {
+	errRot := errors.New("not a letter")
+	rot13 := func(s string) (string, error) {
+		b := []byte(s)
+		for n, c := range b {
+			switch {
+			case 'a' <= c && c <= 'z':
+				b[n] = 'a' + (c-'a'+13)%26
+			default:
+				return "", errRot
+			}
+		}
+		return string(b), nil
+	}
+
+	tests := []struct {
+		name string
+		path string
+		i    int
+		fn   func(string) (string, error)
+		want string
+		ck   checkFunc
+	}{
+		{"rot13", "/t/uryyb", 1, rot13, "hello", unx},
+		{"nil fn", "/t/uryyb", 1, nil, "uryyb", unx},
+		{"fn error", "/t/abc1", 1, rot13, "", exp},
+		{"bad index", "/t", 1, rot13, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringTransform(tt.path, tt.i, tt.fn)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := SegmentToStringTransform("/t/abc1", 1, rot13)
+		if !errors.Is(err, errRot) || errors.Is(err, ErrFirstSegNotFound) {
+			t.Errorf(gwFmt, err, errRot)
+		}
+
+		want := "transform segment 1: not a letter"
+		if err == nil || err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+
+		_, err = SegmentToStringTransform("/t", 1, rot13)
+		if !errors.Is(err, ErrFirstSegNotFound) || errors.Is(err, errRot) {
+			t.Errorf(gwFmt, err, ErrFirstSegNotFound)
+		}
+	})
+}
+
+func TestBhvrSegmentToStringMaxLen(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		max  int
+		want string
+		ck   checkFunc
+	}{
+		{"under", "/q/abc", 1, 4, "abc", unx},
+		{"at limit", "/q/abcd", 1, 4, "abcd", unx},
+		{"over", "/q/abcde", 1, 4, "", exp},
+		{"empty", "/q//x", 1, 0, "", unx},
+		{"bad index", "/q/abc", 2, 4, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringMaxLen(tt.path, tt.i, tt.max)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("message", func(t *testing.T) {
+		_, err := SegmentToStringMaxLen("/q/abcde", 1, 4)
+		if !errors.Is(err, ErrSegTooLong) {
+			t.Fatalf(gwFmt, err, ErrSegTooLong)
+		}
+
+		want := "segment exceeds length limit: 5 bytes, limit is 4"
+		if err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+	})
+}
+
+func TestBhvrSegmentToStringExactLen(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		i      int
+		length int
+		want   string
+		ck     checkFunc
+	}{
+		{"otp", "/otp/123456/", 1, 6, "123456", unx},
+		{"country", "/geo/us", 1, 2, "us", unx},
+		{"short", "/otp/12345", 1, 6, "", exp},
+		{"long", "/otp/1234567", 1, 6, "", exp},
+		{"multibyte bytes", "/geo/\u00e9\u00e9", 1, 4, "\u00e9\u00e9", unx},
+		{"multibyte runes", "/geo/\u00e9\u00e9", 1, 2, "", exp},
+		{"empty", "/otp//", 1, 0, "", unx},
+		{"bad index", "/otp", 1, 6, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringExactLen(tt.path, tt.i, tt.length)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("message", func(t *testing.T) {
+		_, err := SegmentToStringExactLen("/otp/12345", 1, 6)
+		if !errors.Is(err, ErrSegWrongLen) {
+			t.Fatalf(gwFmt, err, ErrSegWrongLen)
+		}
+
+		want := "segment is not the required length: 5 bytes, want 6"
+		if err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+	})
+}
+
+func TestBhvrSegmentToNonEmptyString(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"present", "/a/b/c", 1, "b", unx},
+		{"empty middle", "/a//b", 1, "", exp},
+		{"empty last", "/a/b/", 2, "", exp},
+		{"bad index", "/a/b", 2, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToNonEmptyString(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("sentinels", func(t *testing.T) {
+		_, err := SegmentToNonEmptyString("/a//b", 1)
+		if !errors.Is(err, ErrEmptySegment) {
+			t.Errorf(gwFmt, err, ErrEmptySegment)
+		}
+
+		_, err = SegmentToNonEmptyString("/a/b", 2)
+		if !errors.Is(err, ErrFirstSegNotFound) || errors.Is(err, ErrEmptySegment) {
+			t.Errorf(gwFmt, err, ErrFirstSegNotFound)
+		}
+	})
+}
+
+func TestBhvrSegmentToStringTrimPrefix(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		i      int
+		prefix string
+		want   string
+		ck     checkFunc
+	}{
+		{"tagged", "/users/user_42", 1, "user_", "42", unx},
+		{"absent", "/users/42", 1, "user_", "42", unx},
+		{"once", "/users/user_user_42", 1, "user_", "user_42", unx},
+		{"whole", "/users/user_", 1, "user_", "", unx},
+		{"negative", "/a/user_42", -1, "user_", "42", unx},
+		{"negative trailing slash", "/a/user_42/", -1, "user_", "42", unx},
+		{"bad index", "/users", 1, "user_", "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringTrimPrefix(tt.path, tt.i, tt.prefix)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToStringTrimSuffix(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		i      int
+		suffix string
+		want   string
+		ck     checkFunc
+	}{
+		{"tagged", "/files/report.pdf", 1, ".pdf", "report", unx},
+		{"absent", "/files/report", 1, ".pdf", "report", unx},
+		{"once", "/files/a.pdf.pdf", 1, ".pdf", "a.pdf", unx},
+		{"bad index", "/files", 1, ".pdf", "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringTrimSuffix(tt.path, tt.i, tt.suffix)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToStringCut(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		i          int
+		sep        string
+		wantBefore string
+		wantAfter  string
+		wantFound  bool
+		ck         checkFunc
+	}{
+		{"pair", "/f/name=bob", 1, "=", "name", "bob", true, unx},
+		{"first only", "/f/k:v:w", 1, ":", "k", "v:w", true, unx},
+		{"leading sep", "/f/=bob", 1, "=", "", "bob", true, unx},
+		{"multi byte sep", "/f/a::b", 1, "::", "a", "b", true, unx},
+		{"absent", "/f/name", 1, "=", "name", "", false, unx},
+		{"bad index", "/f", 1, "=", "", "", false, exp},
+	}
+
+	for _, tt := range tests {
+		before, after, found, err := SegmentToStringCut(tt.path, tt.i, tt.sep)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if before != tt.wantBefore || after != tt.wantAfter || found != tt.wantFound {
+			t.Errorf(gwxFmt, tt.name,
+				[]interface{}{before, after, found},
+				[]interface{}{tt.wantBefore, tt.wantAfter, tt.wantFound},
+			)
+		}
+	}
+}
+
+func TestBhvrSegmentToStringList(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		sep  string
+		want []string
+		ck   checkFunc
+	}{
+		{"csv", "/tags/a,b,c/", 1, ",", []string{"a", "b", "c"}, unx},
+		{"single", "/tags/a", 1, ",", []string{"a"}, unx},
+		{"trailing seps", "/tags/a,b,,", 1, ",", []string{"a", "b"}, unx},
+		{"inner empty kept", "/tags/a,,b", 1, ",", []string{"a", "", "b"}, unx},
+		{"leading empty kept", "/tags/,a", 1, ",", []string{"", "a"}, unx},
+		{"multi byte sep", "/tags/a::b", 1, "::", []string{"a", "b"}, unx},
+		{"empty", "/tags//", 1, ",", []string{}, unx},
+		{"only seps", "/tags/,,", 1, ",", []string{}, unx},
+		{"bad index", "/tags", 1, ",", nil, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringList(tt.path, tt.i, tt.sep)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToStringByteRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		i      int
+		start  int
+		length int
+		want   string
+		ck     checkFunc
+	}{
+		{"date", "/id/20230115ABC/", 1, 0, 8, "20230115", unx},
+		{"middle", "/id/20230115ABC", 1, 4, 2, "01", unx},
+		{"tail", "/id/20230115ABC", 1, 8, 3, "ABC", unx},
+		{"negative start", "/id/20230115ABC", 1, -3, 3, "ABC", unx},
+		{"negative partial", "/id/20230115ABC", 1, -3, 1, "A", unx},
+		{"zero length", "/id/abc", 1, 3, 0, "", unx},
+		{"too long", "/id/abc", 1, 1, 3, "", exp},
+		{"start beyond", "/id/abc", 1, 4, 0, "", exp},
+		{"negative beyond", "/id/abc", 1, -4, 1, "", exp},
+		{"negative length", "/id/abc", 1, 1, -1, "", exp},
+		{"length overflow", "/id/abc", 1, 1, math.MaxInt, "", exp},
+		{"start overflow", "/id/abc", 1, math.MaxInt, 1, "", exp},
+		{"bad index", "/id", 1, 0, 1, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringByteRange(tt.path, tt.i, tt.start, tt.length)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("message", func(t *testing.T) {
+		_, err := SegmentToStringByteRange("/id/abc", 1, 1, 3)
+		if !errors.Is(err, ErrDataUnparsable) {
+			t.Fatalf(gwFmt, err, ErrDataUnparsable)
+		}
+
+		want := "data cannot be parsed: byte range 1+3 exceeds segment length 3"
+		if err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+	})
+}
+
+func TestBhvrSegmentToStringFirstOf(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		indexes []int
+		want    string
+		ck      checkFunc
+	}{
+		{"first", "/a/b/c", []int{1, 2}, "b", unx},
+		{"order given", "/a/b/c", []int{2, 1}, "c", unx},
+		{"skip missing", "/a/b", []int{5, 0}, "a", unx},
+		{"skip empty", "/a//c", []int{1, 2}, "c", unx},
+		{"none", "/a//", []int{1, 2, 3}, "", exp},
+		{"no indexes", "/a", nil, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringFirstOf(tt.path, tt.indexes...)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("message", func(t *testing.T) {
+		_, err := SegmentToStringFirstOf("/a//", 1, 2, 3)
+		if !errors.Is(err, ErrFirstSegNotFound) {
+			t.Fatalf(gwFmt, err, ErrFirstSegNotFound)
+		}
+
+		want := "first segment not found by index: no non-empty segment at [1 2 3]"
+		if err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+	})
+}
+
+func TestBhvrSegmentToStringFrom(t *testing.T) {
+	tests := []struct {
+		name     string
+		primary  string
+		fallback string
+		i        int
+		want     string
+		ck       checkFunc
+	}{
+		{"primary", "/a/b", "/x/y", 1, "b", unx},
+		{"primary empty seg", "/a//", "/x/y/z", 1, "", unx},
+		{"fallback", "/a", "/x/y/z", 2, "z", unx},
+		{"empty primary", "", "/x", 0, "x", unx},
+		{"neither", "/a", "/x", 3, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringFrom(tt.primary, tt.fallback, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("message", func(t *testing.T) {
+		_, err := SegmentToStringFrom("/a", "/x", 3)
+		if !errors.Is(err, ErrFirstSegNotFound) {
+			t.Fatalf(gwFmt, err, ErrFirstSegNotFound)
+		}
+
+		want := ErrFirstSegNotFound.Error() + `: tried "/a" and "/x"`
+		if err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+	})
+}
+
+func TestBhvrSegmentToStringReplacer(t *testing.T) {
+	spaces := strings.NewReplacer("_", " ")
+	escapes := strings.NewReplacer("~s", "/", "~~", "~")
+
+	tests := []struct {
+		name string
+		path string
+		i    int
+		r    *strings.Replacer
+		want string
+		ck   checkFunc
+	}{
+		{"underscores", "/n/hello_big_world", 1, spaces, "hello big world", unx},
+		{"custom escapes", "/n/a~sb~~c", 1, escapes, "a/b~c", unx},
+		{"no match", "/n/abc", 1, spaces, "abc", unx},
+		{"nil replacer", "/n/a_b", 1, nil, "a_b", unx},
+		{"bad index", "/n", 1, spaces, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringReplacer(tt.path, tt.i, tt.r)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentTrimExtension(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"json", "/files/report.json", 1, "report", unx},
+		{"last only", "/files/a.tar.gz", 1, "a.tar", unx},
+		{"no dot", "/files/README", 1, "README", unx},
+		{"dotfile", "/files/.gitignore", 1, ".gitignore", unx},
+		{"dotfile ext", "/files/.env.local", 1, ".env", unx},
+		{"trailing dot", "/files/name.", 1, "name", unx},
+		{"negative", "/files/report.json", -1, "report", unx},
+		{"negative trailing slash", "/files/a.tar.gz/", -1, "a.tar", unx},
+		{"bad index", "/files", 1, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentTrimExtension(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToStringHashed(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		h    func() hash.Hash
+		want string
+		ck   checkFunc
+	}{
+		{"sha256", "/k/abc", 1, sha256.New, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", unx},
+		{"sha256 empty", "/k//", 1, sha256.New, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", unx},
+		{"md5", "/k/abc", 1, md5.New, "900150983cd24fb0d6963f7d28e17f72", unx},
+		{"bad index", "/k", 1, sha256.New, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringHashed(tt.path, tt.i, tt.h)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentReader(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"basic", "/blob/abc", 1, "abc", unx},
+		{"empty", "/blob//", 1, "", unx},
+		{"bad index", "/blob", 1, "", exp},
+	}
+
+	for _, tt := range tests {
+		r, err := SegmentReader(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if err != nil {
+			if r != nil {
+				t.Errorf(gwxFmt, tt.name, r, nil)
+			}
+			continue
+		}
+
+		got, err := io.ReadAll(r)
+		if unx(t, tt.name, err) {
+			continue
+		}
+
+		if string(got) != tt.want {
+			t.Errorf(gwxFmt, tt.name, string(got), tt.want)
+		}
+	}
+
+	t.Run("decoder", func(t *testing.T) {
+		r, err := SegmentReader("/blob/aGVsbG8gd29ybGQ=", 1)
+		if unx(t, t.Name(), err) {
+			return
+		}
+
+		got, err := io.ReadAll(base64.NewDecoder(base64.URLEncoding, r))
+		if unx(t, t.Name(), err) {
+			return
+		}
+
+		if string(got) != "hello world" {
+			t.Errorf(gwFmt, string(got), "hello world")
+		}
+	})
+}
+
+func TestBhvrSegmentToStringNFC(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"decomposed", "/u/re\u0301sume\u0301", 1, "r\u00e9sum\u00e9", unx},
+		{"composed", "/u/r\u00e9sum\u00e9", 1, "r\u00e9sum\u00e9", unx},
+		{"compatibility kept", "/u/\ufb01le", 1, "\ufb01le", unx},
+		{"ascii", "/u/bob", 1, "bob", unx},
+		{"bad index", "/u", 1, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringNFC(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToStringNFKC(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"decomposed", "/u/re\u0301sume\u0301", 1, "r\u00e9sum\u00e9", unx},
+		{"ligature", "/u/\ufb01le", 1, "file", unx},
+		{"fullwidth", "/u/\uff22\uff4f\uff42", 1, "Bob", unx},
+		{"bad index", "/u", 1, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringNFKC(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToStringTitle(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"words", "/blog/my first post/", 1, "My First Post", unx},
+		{"mixed case", "/blog/hELLO wORLD", 1, "Hello World", unx},
+		{"dashes", "/blog/my-first-post", 1, "My-First-Post", unx},
+		{"underscores", "/blog/my_first_post", 1, "My_first_post", unx},
+		{"apostrophe", "/blog/don't stop", 1, "Don't Stop", unx},
+		{"unicode", "/blog/\u00e9t\u00e9 ok", 1, "\u00c9t\u00e9 Ok", unx},
+		{"empty", "/blog//", 1, "", unx},
+		{"bad index", "/blog", 1, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringTitle(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToStringTitleWords(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"dashes", "/blog/my-first-post/", 1, "My First Post", unx},
+		{"underscores", "/blog/my_first_post", 1, "My First Post", unx},
+		{"mixed", "/blog/MY-first_Post", 1, "My First Post", unx},
+		{"empty", "/blog//", 1, "", unx},
+		{"bad index", "/blog", 1, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringTitleWords(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToStringUnquoted(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"double", `/name/"bob"/`, 1, "bob", unx},
+		{"single", `/name/'bob'`, 1, "bob", unx},
+		{"escapes", `/name/"a\tb"`, 1, "a\tb", unx},
+		{"single no escapes", `/name/'a\tb'`, 1, `a\tb`, unx},
+		{"empty quotes", `/name/""`, 1, "", unx},
+		{"unquoted", "/name/bob", 1, "bob", unx},
+		{"inner quote", `/name/bo"b`, 1, `bo"b`, unx},
+		{"empty", "/name//", 1, "", unx},
+		{"mismatched", `/name/"bob'`, 1, "", exp},
+		{"dangling start", `/name/"bob`, 1, "", exp},
+		{"dangling end", `/name/bob'`, 1, "", exp},
+		{"lone quote", `/name/"`, 1, "", exp},
+		{"bad escape", `/name/"a\qb"`, 1, "", exp},
+		{"bad index", "/name", 1, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringUnquoted(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
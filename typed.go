@@ -0,0 +1,332 @@
+package parth
+
+import (
+	"encoding/base32"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// SegmentToBoolStrict is similar to Segment when used with a *bool, but only
+// accepts the textual forms "true" and "false" (case-insensitive). Numeric and
+// abbreviated forms such as "1", "0", "t", and "f" are rejected so that a
+// numeric identifier cannot be mistaken for a boolean flag. An error is
+// returned if: 1. The index is out of range of the path; 2. The located path
+// segment is not a textual boolean.
+func SegmentToBoolStrict(path string, i int) (bool, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case strings.EqualFold(s, "true"):
+		return true, nil
+	case strings.EqualFold(s, "false"):
+		return false, nil
+	}
+
+	return false, ErrDataUnparsable
+}
+
+// SegmentToBool01 is similar to SegmentToBoolStrict, but only accepts "1" and
+// "0", which result in true and false respectively. Textual forms such as
+// "true" and "false" are rejected so that numeric flags remain canonical. An
+// error is returned if: 1. The index is out of range of the path; 2. The
+// located path segment is neither "1" nor "0", in which case the error wraps
+// ErrDataUnparsable and quotes the segment.
+func SegmentToBool01(path string, i int) (bool, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return false, err
+	}
+
+	switch s {
+	case "1":
+		return true, nil
+	case "0":
+		return false, nil
+	}
+
+	return false, fmt.Errorf("%w: %q is not 0 or 1", ErrDataUnparsable, s)
+}
+
+// SegmentToTristate locates the path segment indicated by the index i and
+// reports whether it is set along with its boolean value, so that an absent
+// flag can be distinguished from a false one. A segment that is out of range of
+// the path is not set, and (false, false, nil) is returned for it. Otherwise,
+// the segment is set, as with SegmentToStringOk, and is parsed in the same
+// manner as with Segment. An error is returned if it cannot be parsed,
+// including when it is empty (e.g. index 1 of "/notify/").
+func SegmentToTristate(path string, i int) (set bool, value bool, err error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return false, false, nil
+	}
+
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return true, false, ErrDataUnparsable
+	}
+
+	return true, v, nil
+}
+
+// SegmentToSemver locates the path segment indicated by the index i and parses
+// it as a version of the form "vX.Y.Z" (e.g. "v1.2.3" results in 1, 2, and 3).
+// The leading "v" is optional, and a missing minor or patch number defaults to
+// zero (e.g. "v2" results in 2, 0, and 0). Pre-release and build suffixes are
+// not supported. An error is returned if: 1. The index is out of range of the
+// path; 2. The located path segment is not a version, in which case the error
+// wraps ErrDataUnparsable and quotes the segment.
+func SegmentToSemver(path string, i int) (major, minor, patch int, err error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	parts := strings.Split(strings.TrimPrefix(s, "v"), ".")
+	if len(parts) > 3 {
+		return 0, 0, 0, fmt.Errorf("%w: bad version %q", ErrDataUnparsable, s)
+	}
+
+	var vs [3]int
+	for n, part := range parts {
+		if !isDigits(part) {
+			return 0, 0, 0, fmt.Errorf("%w: bad version %q", ErrDataUnparsable, s)
+		}
+
+		if vs[n], err = strconv.Atoi(part); err != nil {
+			return 0, 0, 0, newParseError(i, part, err)
+		}
+	}
+
+	return vs[0], vs[1], vs[2], nil
+}
+
+// SegmentToRuneDecoded locates the path segment indicated by the index i,
+// percent-decodes it using url.PathUnescape, and returns the first rune of the
+// result (e.g. "%2C" results in ','). An error is returned if: 1. The index is
+// out of range of the path; 2. The located path segment cannot be decoded, in
+// which case the decode error is returned; 3. The decoded segment is empty, in
+// which case ErrEmptySegment is returned, or does not begin with valid UTF-8.
+func SegmentToRuneDecoded(path string, i int) (rune, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return 0, err
+	}
+
+	s, err = url.PathUnescape(s)
+	if err != nil {
+		return 0, err
+	}
+
+	if s == "" {
+		return 0, ErrEmptySegment
+	}
+
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError && size == 1 {
+		return 0, ErrDataUnparsable
+	}
+
+	return r, nil
+}
+
+// SegmentToMAC locates the path segment indicated by the index i and parses it
+// as a hardware address using net.ParseMAC. Colon, dash, and period separated
+// forms (e.g. "01:23:45:67:89:ab", "01-23-45-67-89-ab", "0123.4567.89ab") are
+// accepted. An error is returned if: 1. The index is out of range of the path;
+// 2. net.ParseMAC returns an error, in which case that error is returned.
+func SegmentToMAC(path string, i int) (net.HardwareAddr, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return nil, err
+	}
+
+	return net.ParseMAC(s)
+}
+
+// SegmentToUUID locates the path segment indicated by the index i and
+// validates it as a UUID in the canonical 8-4-4-4-12 hexadecimal form. The
+// returned string is normalized to lowercase. An error is returned if: 1. The
+// index is out of range of the path; 2. The located path segment is not a
+// canonical UUID.
+func SegmentToUUID(path string, i int) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	if !isUUID(s) {
+		return "", ErrDataUnparsable
+	}
+
+	return strings.ToLower(s), nil
+}
+
+// SegmentToHexString locates the path segment indicated by the index i and
+// validates that it consists only of hexadecimal digits, in either case. The
+// segment is not decoded, so an odd length is allowed, and the returned string
+// is normalized to lowercase. An error is returned if: 1. The index is out of
+// range of the path; 2. The located path segment is empty or contains a
+// non-hexadecimal character.
+func SegmentToHexString(path string, i int) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	if s == "" {
+		return "", ErrDataUnparsable
+	}
+
+	for n := 0; n < len(s); n++ {
+		if !isHexDigit(s[n]) {
+			return "", ErrDataUnparsable
+		}
+	}
+
+	return strings.ToLower(s), nil
+}
+
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+
+	for n := 0; n < len(s); n++ {
+		switch n {
+		case 8, 13, 18, 23:
+			if s[n] != '-' {
+				return false
+			}
+		default:
+			if !isHexDigit(s[n]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func isHexDigit(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// SegmentToEnum locates the path segment indicated by the index i and returns
+// the value that it is mapped to within table. An error is returned if: 1. The
+// index is out of range of the path; 2. The located path segment is not a key
+// of table, in which case the error wraps ErrUnknownEnum and lists the valid
+// keys.
+func SegmentToEnum[T any](path string, i int, table map[string]T) (T, error) {
+	var zero T
+
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return zero, err
+	}
+
+	v, ok := table[s]
+	if !ok {
+		keys := make([]string, 0, len(table))
+		for k := range table {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		return zero, fmt.Errorf("%w %q (valid: %s)", ErrUnknownEnum, s, strings.Join(keys, ", "))
+	}
+
+	return v, nil
+}
+
+// SegmentToStringEnum locates the path segment indicated by the index i and
+// returns it if it is exactly equal to one of the allowed values. An error is
+// returned if: 1. The index is out of range of the path; 2. The located path
+// segment is not allowed, in which case the error wraps ErrUnknownEnum and
+// lists the allowed values.
+func SegmentToStringEnum(path string, i int, allowed ...string) (string, error) {
+	return segmentToStringEnum(path, i, allowed, func(a, b string) bool {
+		return a == b
+	})
+}
+
+// SegmentToStringEnumFold is similar to SegmentToStringEnum, but compares the
+// located path segment to the allowed values without regard to case, and
+// returns the matching allowed value (e.g. "ASC" with "asc" allowed results in
+// "asc").
+func SegmentToStringEnumFold(path string, i int, allowed ...string) (string, error) {
+	return segmentToStringEnum(path, i, allowed, strings.EqualFold)
+}
+
+func segmentToStringEnum(path string, i int, allowed []string, eq func(string, string) bool) (string, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return "", err
+	}
+
+	for _, a := range allowed {
+		if eq(s, a) {
+			return a, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w %q (valid: %s)", ErrUnknownEnum, s, strings.Join(allowed, ", "))
+}
+
+// SegmentToBytesBase32 locates the path segment indicated by the index i and
+// decodes it using base32.StdEncoding. Padding is optional, but if present it
+// must be correct. An error is returned if: 1. The index is out of range of the
+// path; 2. The located path segment cannot be decoded, in which case the
+// encoding error is returned.
+func SegmentToBytesBase32(path string, i int) ([]byte, error) {
+	return SegmentToBytesBase32Encoding(path, i, base32.StdEncoding)
+}
+
+// SegmentToBytesBase32Encoding is similar to SegmentToBytesBase32, but decodes
+// using the provided encoding (e.g. base32.HexEncoding).
+func SegmentToBytesBase32Encoding(path string, i int, enc *base32.Encoding) ([]byte, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.ContainsRune(s, base32.StdPadding) {
+		enc = enc.WithPadding(base32.NoPadding)
+	}
+
+	return enc.DecodeString(s)
+}
+
+// SegmentToIPNet locates the path segment indicated by the index i and parses
+// it as a CIDR notation IP address and prefix length using net.ParseCIDR.
+// Because a path segment cannot contain a slash, the last occurrence of sep
+// within the segment is treated as the slash (e.g. "10.0.0.0_8" with a sep of
+// "_"). If sep is empty, the segment is parsed as-is. An error is returned if:
+// 1. The index is out of range of the path; 2. net.ParseCIDR returns an error,
+// in which case that error is returned.
+func SegmentToIPNet(path string, i int, sep string) (*net.IPNet, error) {
+	s, err := segmentToString(path, i)
+	if err != nil {
+		return nil, err
+	}
+
+	if sep != "" {
+		if n := strings.LastIndex(s, sep); n >= 0 {
+			s = s[:n] + "/" + s[n+len(sep):]
+		}
+	}
+
+	_, ipn, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return ipn, nil
+}
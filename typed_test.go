@@ -0,0 +1,498 @@
+package parth
+
+import (
+	"bytes"
+	"encoding/base32"
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestBhvrSegmentToBoolStrict(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want bool
+		ck   checkFunc
+	}{
+		{"true", "/flag/true", 1, true, unx},
+		{"false", "/flag/false", 1, false, unx},
+		{"upper", "/flag/TRUE", 1, true, unx},
+		{"mixed", "/flag/False", 1, false, unx},
+		{"one", "/flag/1", 1, false, exp},
+		{"zero", "/flag/0", 1, false, exp},
+		{"t", "/flag/t", 1, false, exp},
+		{"f", "/flag/f", 1, false, exp},
+		{"noise", "/flag/truex", 1, false, exp},
+		{"bad index", "/flag/true", 2, false, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToBoolStrict(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToBool01(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want bool
+		ck   checkFunc
+	}{
+		{"one", "/flag/1", 1, true, unx},
+		{"zero", "/flag/0/", 1, false, unx},
+		{"true", "/flag/true", 1, false, exp},
+		{"false", "/flag/false", 1, false, exp},
+		{"t", "/flag/t", 1, false, exp},
+		{"padded", "/flag/01", 1, false, exp},
+		{"two", "/flag/2", 1, false, exp},
+		{"empty", "/flag//", 1, false, exp},
+		{"bad index", "/flag", 1, false, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToBool01(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("message", func(t *testing.T) {
+		_, err := SegmentToBool01("/flag/true", 1)
+		if !errors.Is(err, ErrDataUnparsable) {
+			t.Fatalf(gwFmt, err, ErrDataUnparsable)
+		}
+
+		want := `data cannot be parsed: "true" is not 0 or 1`
+		if err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+	})
+}
+
+func TestBhvrSegmentToTristate(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		i       int
+		wantSet bool
+		want    bool
+		ck      checkFunc
+	}{
+		{"true", "/notify/true/", 1, true, true, unx},
+		{"false", "/notify/false/", 1, true, false, unx},
+		{"numeric", "/notify/1", 1, true, true, unx},
+		{"absent", "/notify", 1, false, false, unx},
+		{"empty", "/notify/", 1, true, false, exp},
+		{"empty inner", "/a//b", 1, true, false, exp},
+		{"unparsable", "/notify/maybe", 1, true, false, exp},
+		{"negative", "/notify/false", -1, true, false, unx},
+		{"negative trailing slash", "/notify/true/", -1, true, true, unx},
+		{"negative absent", "/notify", -2, false, false, unx},
+	}
+
+	for _, tt := range tests {
+		gotSet, got, err := SegmentToTristate(tt.path, tt.i)
+		if gotSet != tt.wantSet {
+			t.Errorf(gwxFmt, tt.name, gotSet, tt.wantSet)
+		}
+
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToSemver(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want [3]int
+		ck   checkFunc
+	}{
+		{"full", "/api/v1.2.3/", 1, [3]int{1, 2, 3}, unx},
+		{"no v", "/api/1.2.3", 1, [3]int{1, 2, 3}, unx},
+		{"major only", "/api/v2", 1, [3]int{2, 0, 0}, unx},
+		{"major minor", "/api/v2.5", 1, [3]int{2, 5, 0}, unx},
+		{"multi digit", "/api/v10.20.300", 1, [3]int{10, 20, 300}, unx},
+		{"too many parts", "/api/v1.2.3.4", 1, [3]int{}, exp},
+		{"empty part", "/api/v1..3", 1, [3]int{}, exp},
+		{"trailing dot", "/api/v1.", 1, [3]int{}, exp},
+		{"bare v", "/api/v", 1, [3]int{}, exp},
+		{"letters", "/api/vx.y", 1, [3]int{}, exp},
+		{"pre-release", "/api/v1.2.3-beta", 1, [3]int{}, exp},
+		{"negative", "/api/v-1.2", 1, [3]int{}, exp},
+		{"overflow", "/api/v99999999999999999999", 1, [3]int{}, exp},
+		{"bad index", "/api", 1, [3]int{}, exp},
+	}
+
+	for _, tt := range tests {
+		major, minor, patch, err := SegmentToSemver(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got := [3]int{major, minor, patch}; got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("message", func(t *testing.T) {
+		_, _, _, err := SegmentToSemver("/api/v1..3", 1)
+		if !errors.Is(err, ErrDataUnparsable) {
+			t.Fatalf(gwFmt, err, ErrDataUnparsable)
+		}
+
+		want := `data cannot be parsed: bad version "v1..3"`
+		if err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+	})
+}
+
+func TestBhvrSegmentToRuneDecoded(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want rune
+		ck   checkFunc
+	}{
+		{"comma", "/sep/%2C/", 1, ',', unx},
+		{"slash", "/sep/%2F", 1, '/', unx},
+		{"plain", "/sep/x", 1, 'x', unx},
+		{"first of many", "/sep/ab", 1, 'a', unx},
+		{"multibyte", "/sep/%C3%A9", 1, 'é', unx},
+		{"invalid escape", "/sep/%zz", 1, 0, exp},
+		{"invalid utf8", "/sep/%FF", 1, 0, exp},
+		{"empty", "/sep//", 1, 0, exp},
+		{"bad index", "/sep", 1, 0, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToRuneDecoded(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		_, err := SegmentToRuneDecoded("/sep//", 1)
+		if !errors.Is(err, ErrEmptySegment) {
+			t.Errorf(gwFmt, err, ErrEmptySegment)
+		}
+	})
+}
+
+func TestBhvrSegmentToMAC(t *testing.T) {
+	want := net.HardwareAddr{0x01, 0x23, 0x45, 0x67, 0x89, 0xab}
+
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want net.HardwareAddr
+		ck   checkFunc
+	}{
+		{"colon", "/nic/01:23:45:67:89:ab/stats", 1, want, unx},
+		{"dash", "/nic/01-23-45-67-89-AB/stats", 1, want, unx},
+		{"period", "/nic/0123.4567.89ab/stats", 1, want, unx},
+		{"malformed", "/nic/01:23:45/stats", 1, nil, exp},
+		{"bad index", "/nic/01:23:45:67:89:ab/stats", 3, nil, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToMAC(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if !bytes.Equal(got, tt.want) {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToUUID(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"valid", "/res/123e4567-e89b-12d3-a456-426614174000", 1, "123e4567-e89b-12d3-a456-426614174000", unx},
+		{"upper", "/res/123E4567-E89B-12D3-A456-426614174000/", 1, "123e4567-e89b-12d3-a456-426614174000", unx},
+		{"negative", "/res/123e4567-e89b-12d3-a456-426614174000", -1, "123e4567-e89b-12d3-a456-426614174000", unx},
+		{"negative trailing slash", "/123e4567-e89b-12d3-a456-426614174000/res/", -2, "123e4567-e89b-12d3-a456-426614174000", unx},
+		{"short", "/res/123e4567-e89b-12d3-a456-42661417400", 1, "", exp},
+		{"long", "/res/123e4567-e89b-12d3-a456-4266141740000", 1, "", exp},
+		{"non-hex", "/res/123e4567-e89b-12d3-a456-42661417400g", 1, "", exp},
+		{"misplaced dash", "/res/123e4567e-89b-12d3-a456-426614174000", 1, "", exp},
+		{"bad index", "/res/123e4567-e89b-12d3-a456-426614174000", 2, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToUUID(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToHexString(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"lower", "/tok/deadbeef", 1, "deadbeef", unx},
+		{"upper", "/tok/DEADBEEF/", 1, "deadbeef", unx},
+		{"mixed", "/tok/0aF9", 1, "0af9", unx},
+		{"odd length", "/tok/abc", 1, "abc", unx},
+		{"prefix", "/tok/0xff", 1, "", exp},
+		{"non-hex", "/tok/abcg", 1, "", exp},
+		{"non-ascii", "/tok/ab\u00e9", 1, "", exp},
+		{"empty", "/tok//", 1, "", exp},
+		{"bad index", "/tok", 1, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToHexString(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToEnum(t *testing.T) {
+	type status int
+
+	table := map[string]status{
+		"active":   1,
+		"inactive": 2,
+	}
+
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want status
+		ck   checkFunc
+	}{
+		{"active", "/status/active/", 1, 1, unx},
+		{"inactive", "/status/inactive", 1, 2, unx},
+		{"unknown", "/status/paused", 1, 0, exp},
+		{"bad index", "/status/active", 2, 0, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToEnum(tt.path, tt.i, table)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("message", func(t *testing.T) {
+		_, err := SegmentToEnum("/status/paused", 1, table)
+		if !errors.Is(err, ErrUnknownEnum) {
+			t.Fatalf(gwFmt, err, ErrUnknownEnum)
+		}
+
+		want := `unknown enum value "paused" (valid: active, inactive)`
+		if err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+	})
+}
+
+func TestBhvrSegmentToStringEnum(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"asc", "/sort/asc", 1, "asc", unx},
+		{"desc", "/sort/desc/", 1, "desc", unx},
+		{"case differs", "/sort/ASC", 1, "", exp},
+		{"unknown", "/sort/up", 1, "", exp},
+		{"bad index", "/sort", 1, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringEnum(tt.path, tt.i, "asc", "desc")
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+
+	t.Run("message", func(t *testing.T) {
+		_, err := SegmentToStringEnum("/sort/up", 1, "asc", "desc")
+		if !errors.Is(err, ErrUnknownEnum) {
+			t.Fatalf(gwFmt, err, ErrUnknownEnum)
+		}
+
+		want := `unknown enum value "up" (valid: asc, desc)`
+		if err.Error() != want {
+			t.Errorf(gwFmt, err, want)
+		}
+	})
+}
+
+func TestBhvrSegmentToStringEnumFold(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want string
+		ck   checkFunc
+	}{
+		{"exact", "/sort/asc", 1, "asc", unx},
+		{"upper", "/sort/ASC", 1, "asc", unx},
+		{"mixed", "/sort/DeSc", 1, "desc", unx},
+		{"unknown", "/sort/up", 1, "", exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToStringEnumFold(tt.path, tt.i, "asc", "desc")
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToBytesBase32(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want []byte
+		ck   checkFunc
+	}{
+		{"padded", "/b/MZXW6YQ=/", 1, []byte("foob"), unx},
+		{"unpadded", "/b/MZXW6YQ", 1, []byte("foob"), unx},
+		{"full block", "/b/MZXW6YTB", 1, []byte("fooba"), unx},
+		{"empty", "/b//x", 1, []byte{}, unx},
+		{"bad padding", "/b/M=======", 1, nil, exp},
+		{"bad char", "/b/MZXW6Y1", 1, nil, exp},
+		{"bad index", "/b/MZXW6YQ", 2, nil, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToBytesBase32(tt.path, tt.i)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if !bytes.Equal(got, tt.want) {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToBytesBase32Encoding(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		want []byte
+		ck   checkFunc
+	}{
+		{"padded", "/b/CPNMUOG=", 1, []byte("foob"), unx},
+		{"unpadded", "/b/CPNMUOG", 1, []byte("foob"), unx},
+		{"std alphabet", "/b/MZXW6YQ", 1, nil, exp},
+	}
+
+	for _, tt := range tests {
+		got, err := SegmentToBytesBase32Encoding(tt.path, tt.i, base32.HexEncoding)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		if !bytes.Equal(got, tt.want) {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBhvrSegmentToIPNet(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		i    int
+		sep  string
+		want string
+		ck   checkFunc
+	}{
+		{"v4", "/subnet/10.0.0.0_8/", 1, "_", "10.0.0.0/8", unx},
+		{"v4 host bits", "/subnet/192.168.1.7_24", 1, "_", "192.168.1.0/24", unx},
+		{"v6", "/subnet/2001:db8::_32", 1, "_", "2001:db8::/32", unx},
+		{"multi-char sep", "/subnet/10.0.0.0--8", 1, "--", "10.0.0.0/8", unx},
+		{"no sep", "/subnet/10.0.0.0", 1, "_", "", exp},
+		{"empty sep", "/subnet/10.0.0.0_8", 1, "", "", exp},
+		{"bad prefix", "/subnet/10.0.0.0_33", 1, "_", "", exp},
+		{"bad index", "/subnet/10.0.0.0_8", 2, "_", "", exp},
+	}
+
+	for _, tt := range tests {
+		ipn, err := SegmentToIPNet(tt.path, tt.i, tt.sep)
+		if tt.ck(t, tt.name, err) {
+			continue
+		}
+
+		var got string
+		if ipn != nil {
+			got = ipn.String()
+		}
+
+		if got != tt.want {
+			t.Errorf(gwxFmt, tt.name, got, tt.want)
+		}
+	}
+}